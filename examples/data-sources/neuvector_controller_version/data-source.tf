@@ -0,0 +1 @@
+data "neuvector_controller_version" "test" {}
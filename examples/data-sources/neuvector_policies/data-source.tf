@@ -0,0 +1,30 @@
+data "neuvector_policies" "test" {}
+
+data "neuvector_policies" "containers_web" {
+  match = "all"
+
+  filter {
+    field = "from"
+    value = "containers"
+  }
+
+  filter {
+    field    = "applications"
+    operator = "regex"
+    value    = "^HTTPS?$"
+  }
+}
+
+data "neuvector_policies" "learned_or_disabled" {
+  match = "any"
+
+  filter {
+    field = "learned"
+    value = "true"
+  }
+
+  filter {
+    field = "disable"
+    value = "true"
+  }
+}
@@ -0,0 +1,19 @@
+data "neuvector_policy_analysis" "test" {
+  rule {
+    action       = "allow"
+    applications = ["HTTP"]
+    comment      = "Planned web access"
+    from         = "containers"
+    to           = "nodes"
+    ports        = "tcp/80"
+  }
+
+  rule {
+    action       = "deny"
+    applications = ["any"]
+    comment      = "Planned web deny"
+    from         = "containers"
+    to           = "nodes"
+    ports        = "tcp/80"
+  }
+}
@@ -15,6 +15,8 @@ data "neuvector_policy_ids" "test" {}
 #   applications = ["HTTP"]
 # }
 
+# Needs a federation master
 # data "neuvector_policy_ids" "federation_rules" {
-#   cfg_type = "federal"
+#   rules_scope = "federal"
+#   cfg_type    = "federal"
 # }
@@ -0,0 +1,14 @@
+resource "neuvector_policy" "stats" {
+  rule {
+    action       = "allow"
+    applications = ["HTTP"]
+    comment      = "Measured web access"
+    from         = "containers"
+    to           = "nodes"
+    ports        = "tcp/80"
+  }
+}
+
+data "neuvector_policy_rule_stats" "test" {
+  policy_ids = neuvector_policy.stats.policy_ids
+}
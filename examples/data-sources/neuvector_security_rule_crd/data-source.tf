@@ -0,0 +1,8 @@
+data "neuvector_security_rule_crd" "test" {
+  groups = ["containers", "nodes"]
+}
+
+data "neuvector_security_rule_crd" "network_only" {
+  groups          = ["containers"]
+  include_process = false
+}
@@ -10,3 +10,24 @@ provider "neuvector" {
   password = "admin"
   insecure = false
 }
+
+provider "neuvector" {
+  base_url       = "https://localhost:10443/v1"
+  api_key_name   = "ci"
+  api_key_secret = "secret"
+}
+
+provider "neuvector" {
+  base_url        = "https://neuvector.internal/v1"
+  username        = "admin"
+  password        = "admin"
+  ca_cert_file    = "/etc/ssl/certs/internal-ca.pem"
+  tls_server_name = "neuvector.internal"
+}
+
+provider "neuvector" {
+  base_url    = "https://localhost:10443/v1"
+  username    = "jdoe"
+  password    = "password"
+  auth_server = "corp-ad"
+}
@@ -0,0 +1 @@
+terraform import neuvector_admission_control.name 0
@@ -0,0 +1,10 @@
+resource "neuvector_admission_control" "test" {
+  enable         = true
+  mode           = "monitor"
+  default_action = "allow"
+  client_mode    = "service"
+  failure_policy = "ignore"
+
+  exempt_neuvector_namespace = true
+  neuvector_namespace        = "neuvector"
+}
@@ -31,8 +31,32 @@ resource "neuvector_policy" "test" {
   }
 }
 
+resource "neuvector_policy" "ordered" {
+  position = "top"
+
+  ordered_rule {
+    action       = "allow"
+    applications = ["DNS"]
+    comment      = "Containers DNS"
+    from         = "containers"
+    to           = "nodes"
+    ports        = "udp/53"
+  }
+
+  ordered_rule {
+    action       = "deny"
+    applications = ["any"]
+    comment      = "Containers to nodes"
+    from         = "containers"
+    to           = "nodes"
+    ports        = "any"
+  }
+}
+
+# Needs a federation master
 # resource "neuvector_policy" "fed_containers" {
 #   rules_scope = "federal"
+#
 #   rule {
 #     action       = "deny"
 #     applications = ["any"]
@@ -0,0 +1,7 @@
+resource "neuvector_policy_promotion" "test" {
+  from = "nodes"
+}
+
+# resource "neuvector_policy_promotion" "by_ids" {
+#   policy_ids = [10001, 10002]
+# }
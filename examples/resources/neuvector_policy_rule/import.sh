@@ -0,0 +1 @@
+terraform import neuvector_policy_rule.name {{policy_id}}
\ No newline at end of file
@@ -0,0 +1,8 @@
+resource "neuvector_policy_rule" "test" {
+  action       = "deny"
+  applications = ["HTTP"]
+  comment      = "Nodes web constraints"
+  from         = "nodes"
+  to           = "containers"
+  ports        = "tcp/80"
+}
@@ -0,0 +1,291 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+const (
+	// Header carrying the session token
+	TokenHeader = "X-Auth-Token"
+	// Header carrying the API key, formatted as `name:secret`
+	APIKeyHeader = "X-R-APIKEY"
+)
+
+// Session idle timeout used when the controller doesn't report it
+const DefaultSessionTimeout = 300 * time.Second
+
+// Represents the NeuVector token JSON object
+//
+// Unlike goneuvector.TokenResponse, it keeps the session idle timeout
+type tokenResponse struct {
+	Token struct {
+		// Token value
+		Token string `json:"token"`
+		// Session idle timeout in seconds
+		Timeout int `json:"timeout"`
+	} `json:"token"`
+}
+
+// Transport adding the NeuVector credentials to every request
+//
+// With user credentials, the session token is renewed before its
+// idle timeout and once again if the controller rejects it.
+type authTransport struct {
+	// Next transport in the chain
+	next http.RoundTripper
+	// Credentials
+	config *Config
+	// Protects the session fields, held during a login
+	mu sync.Mutex
+	// Session token, only used without API key
+	token string
+	// Session idle timeout
+	timeout time.Duration
+	// Time after which the controller drops the session
+	expiresAt time.Time
+}
+
+func newAuthTransport(config *Config, next http.RoundTripper) *authTransport {
+	return &authTransport{
+		next:   next,
+		config: config,
+	}
+}
+
+// Get a session token when the client doesn't use an API key
+func (t *authTransport) Authenticate(ctx context.Context) error {
+	if t.config.HasAPIKey() {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.login(ctx)
+}
+
+// Log in with the user credentials then store the session token
+//
+// The caller must hold `t.mu`
+func (t *authTransport) login(ctx context.Context) error {
+	auth := goneuvector.NewClientAuth(
+		t.config.Username,
+		t.config.Password,
+	)
+
+	body, err := json.Marshal(auth)
+
+	if err != nil {
+		return err
+	}
+
+	endpoint := t.config.BaseUrl + "/auth"
+
+	// Users of a remote server log in through its own endpoint
+	if t.config.AuthServer != "" {
+		endpoint += "/" + url.PathEscape(t.config.AuthServer)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		endpoint,
+		bytes.NewReader(body),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", goneuvector.DefaultContentType)
+
+	resp, err := t.next.RoundTrip(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &goneuvector.APIError{
+			StatusCode: resp.StatusCode,
+			Reason:     "Invalid authentication",
+		}
+	}
+
+	var token tokenResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return err
+	}
+
+	t.token = token.Token.Token
+	t.timeout = time.Duration(token.Token.Timeout) * time.Second
+
+	if t.timeout <= 0 {
+		t.timeout = DefaultSessionTimeout
+	}
+
+	t.expiresAt = time.Now().Add(t.timeout)
+
+	return nil
+}
+
+// Returns a valid session token, logging in again
+// if the session is about to reach its idle timeout
+func (t *authTransport) sessionToken(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Keeping a margin for the request round trip
+	margin := t.timeout / 10
+
+	if time.Now().Add(margin).After(t.expiresAt) {
+		if err := t.login(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	return t.token, nil
+}
+
+// Log in again unless another request already replaced `expired`
+func (t *authTransport) renew(ctx context.Context, expired string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != expired {
+		return t.token, nil
+	}
+
+	if err := t.login(ctx); err != nil {
+		return "", err
+	}
+
+	return t.token, nil
+}
+
+// Postpone the session expiration, the controller timeout being an idle one
+func (t *authTransport) touch(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == token {
+		t.expiresAt = time.Now().Add(t.timeout)
+	}
+}
+
+// Send a copy of `req` with the credentials headers
+func (t *authTransport) send(req *http.Request, token string) (*http.Response, error) {
+	r, err := cloneRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.Header.Del(TokenHeader)
+
+	if t.config.HasAPIKey() {
+		r.Header.Set(
+			APIKeyHeader,
+			t.config.APIKeyName+":"+t.config.APIKeySecret,
+		)
+	} else {
+		r.Header.Set(TokenHeader, token)
+	}
+
+	return t.next.RoundTrip(r)
+}
+
+// Returns if the controller rejected the session token
+func isSessionExpired(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusUnauthorized ||
+		resp.StatusCode == http.StatusRequestTimeout
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.config.HasAPIKey() {
+		resp, err := t.send(req, "")
+
+		return guardRequestTimeout(req, resp, err)
+	}
+
+	token, err := t.sessionToken(req.Context())
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.send(req, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSessionExpired(resp) {
+		t.touch(token)
+
+		return resp, nil
+	}
+
+	resp.Body.Close()
+
+	// Single re-authentication then replaying the request
+	token, err = t.renew(req.Context(), token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err = t.send(req, token)
+
+	if err == nil && !isSessionExpired(resp) {
+		t.touch(token)
+	}
+
+	return guardRequestTimeout(req, resp, err)
+}
+
+// go-neuvector logs in again with its own credentials then retries
+// forever on 408, so this status never reaches it.
+func guardRequestTimeout(req *http.Request, resp *http.Response, err error) (*http.Response, error) {
+	if err != nil || resp.StatusCode != http.StatusRequestTimeout {
+		return resp, err
+	}
+
+	resp.Body.Close()
+
+	return nil, &goneuvector.APIError{
+		StatusCode: http.StatusRequestTimeout,
+		Reason:     fmt.Sprintf("(%s) (%s) session expired", req.Method, req.URL.Path),
+	}
+}
+
+// Returns a copy of `req` with a fresh body, so it can be sent again
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+
+	if req.GetBody == nil {
+		return r, nil
+	}
+
+	body, err := req.GetBody()
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.Body = body
+
+	return r, nil
+}
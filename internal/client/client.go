@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+	"unsafe"
+
+	"github.com/theobori/go-neuvector/logger"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+// Provider side configuration used to build the NeuVector client
+type Config struct {
+	// Controller REST API base url
+	BaseUrl string
+	// TLS skip invalid certificate
+	Insecure bool
+	// PEM encoded CA bundle verifying the controller certificate
+	CACertPEM string
+	// PEM encoded client certificate, for mutual TLS
+	ClientCertPEM string
+	// PEM encoded client private key
+	ClientKeyPEM string
+	// Server name verified instead of the base url host
+	TLSServerName string
+	// User username
+	Username string
+	// User password
+	Password string
+	// Remote authentication server name (LDAP, Active Directory, ...),
+	// the user is a local one when empty
+	AuthServer string
+	// API key name, used instead of the user credentials when set
+	APIKeyName string
+	// API key secret
+	APIKeySecret string
+	// Federation member cluster managed through the master proxy
+	ManagedClusterID string
+	// Refuse every request modifying the controller state
+	ReadOnly bool
+	// Maximum amount of requests sent at the same time, unlimited if 0
+	MaxConcurrentRequests int
+	// Maximum amount of requests sent per second, unlimited if 0
+	RequestsPerSecond float64
+	// Maximum amount of retries for an idempotent request
+	MaxRetries int
+	// Wait before the first retry
+	RetryMinWait time.Duration
+	// Maximum wait between two retries
+	RetryMaxWait time.Duration
+}
+
+// Returns if the client must authenticate with an API key
+func (config *Config) HasAPIKey() bool {
+	return config.APIKeyName != "" || config.APIKeySecret != ""
+}
+
+// Returns the TLS configuration used to reach the controller
+func (config *Config) GetTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.Insecure,
+		ServerName:         config.TLSServerName,
+	}
+
+	if config.CACertPEM != "" {
+		pool := x509.NewCertPool()
+
+		if !pool.AppendCertsFromPEM([]byte(config.CACertPEM)) {
+			return nil, fmt.Errorf("unable to find a PEM certificate in the CA bundle")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCertPEM != "" || config.ClientKeyPEM != "" {
+		cert, err := tls.X509KeyPair(
+			[]byte(config.ClientCertPEM),
+			[]byte(config.ClientKeyPEM),
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// Returns the transport sending the requests on the network
+func (config *Config) GetHTTPTransport() (*http.Transport, error) {
+	tlsConfig, err := config.GetTLSConfig()
+
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// go-neuvector doesn't let the caller provide its HTTP client,
+// so the unexported field is replaced to plug the provider transport.
+func setHTTPClient(c *goneuvector.Client, httpClient *http.Client) {
+	field := reflect.ValueOf(c).Elem().FieldByName("client")
+
+	reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).
+		Elem().
+		Set(reflect.ValueOf(httpClient))
+}
+
+// Instanciates a new authenticated goneuvector.Client
+// sending every request through the provider transport
+func NewClient(ctx context.Context, config *Config) (*goneuvector.Client, error) {
+	base, err := config.GetHTTPTransport()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var next http.RoundTripper = newLoggingTransport(ctx, base)
+
+	next = newLimiterTransport(config, next)
+	next = newRetryTransport(config, next)
+
+	transport := newAuthTransport(config, next)
+
+	if err := transport.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	c := &goneuvector.Client{
+		BaseUrl: config.BaseUrl,
+		Logger:  logger.Info,
+	}
+
+	var top http.RoundTripper = newFederationTransport(config, transport)
+
+	if config.ReadOnly {
+		top = newReadOnlyTransport(top)
+	}
+
+	setHTTPClient(c, &http.Client{Transport: top})
+	c.WithBackgroungContext()
+
+	return c, nil
+}
@@ -0,0 +1,236 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+)
+
+// Fake NeuVector controller
+type testController struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// Amount of successful logins
+	logins int
+	// Path of the last login
+	loginPath string
+	// Only valid session token
+	token string
+	// Session idle timeout returned at login, in seconds
+	timeout int
+	// Status answered for an invalid session
+	expiredStatus int
+}
+
+// Returns a fake controller, routes not handled by `handler`
+// answers 200 when the credentials are valid
+func newTestController(t *testing.T, handler http.HandlerFunc) *testController {
+	t.Helper()
+
+	c := &testController{
+		timeout:       300,
+		expiredStatus: http.StatusRequestTimeout,
+	}
+
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v1")
+
+		if strings.HasPrefix(path, "/auth") {
+			c.login(w, path)
+			return
+		}
+
+		if !c.isAuthenticated(r) {
+			w.WriteHeader(c.expiredStatus)
+			return
+		}
+
+		if handler != nil {
+			handler(w, r)
+			return
+		}
+
+		w.Write([]byte(`{}`))
+	}))
+
+	t.Cleanup(c.Server.Close)
+
+	return c
+}
+
+func (c *testController) login(w http.ResponseWriter, path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.logins++
+	c.loginPath = path
+	c.token = fmt.Sprintf("token-%d", c.logins)
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"token": map[string]any{
+			"token":   c.token,
+			"timeout": c.timeout,
+		},
+	})
+}
+
+func (c *testController) isAuthenticated(r *http.Request) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return r.Header.Get(client.TokenHeader) == c.token ||
+		r.Header.Get(client.APIKeyHeader) == "key:secret"
+}
+
+// Drops the current session
+func (c *testController) expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token = "expired"
+}
+
+func (c *testController) loginCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.logins
+}
+
+func (c *testController) config() *client.Config {
+	return &client.Config{
+		BaseUrl:  c.URL + "/v1",
+		Username: "admin",
+		Password: "admin",
+	}
+}
+
+func newTestClient(t *testing.T, config *client.Config) *goneuvector.Client {
+	t.Helper()
+
+	c, err := client.NewClient(context.Background(), config)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestNewClientPassword(t *testing.T) {
+	controller := newTestController(t, nil)
+	c := newTestClient(t, controller.config())
+
+	if err := c.Get("/eula", nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewClientAuthServer(t *testing.T) {
+	controller := newTestController(t, nil)
+
+	config := controller.config()
+	config.AuthServer = "corp ad"
+
+	c := newTestClient(t, config)
+
+	if err := c.Get("/eula", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if controller.loginPath != "/auth/corp ad" {
+		t.Fatalf("logged in through %q", controller.loginPath)
+	}
+}
+
+func TestNewClientAPIKey(t *testing.T) {
+	controller := newTestController(t, nil)
+	c := newTestClient(t, &client.Config{
+		BaseUrl:      controller.URL + "/v1",
+		APIKeyName:   "key",
+		APIKeySecret: "secret",
+	})
+
+	if err := c.Get("/eula", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if controller.loginCount() != 0 {
+		t.Fatal("the API key must not log in")
+	}
+}
+
+func TestSessionRenewal(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusRequestTimeout} {
+		var body string
+
+		controller := newTestController(t, func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+		})
+
+		controller.expiredStatus = status
+
+		c := newTestClient(t, controller.config())
+
+		controller.expire()
+
+		err := c.Patch("/policy/rule", map[string]any{"delete": []int{1}}, nil)
+
+		if err != nil {
+			t.Fatalf("%d: %s", status, err)
+		}
+
+		if controller.loginCount() != 2 {
+			t.Fatalf("%d: expected 2 logins, got %d", status, controller.loginCount())
+		}
+
+		if body != `{"delete":[1]}` {
+			t.Fatalf("%d: the replayed body is %q", status, body)
+		}
+	}
+}
+
+func TestSessionRenewalOnce(t *testing.T) {
+	controller := newTestController(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	c := newTestClient(t, controller.config())
+
+	if err := c.Get("/eula", nil); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if controller.loginCount() != 2 {
+		t.Fatalf("expected 2 logins, got %d", controller.loginCount())
+	}
+}
+
+func TestSessionProactiveRenewal(t *testing.T) {
+	controller := newTestController(t, nil)
+	controller.timeout = 1
+
+	c := newTestClient(t, controller.config())
+
+	time.Sleep(time.Second)
+
+	if err := c.Get("/eula", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if controller.loginCount() != 2 {
+		t.Fatalf("expected 2 logins, got %d", controller.loginCount())
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+// Returns the master proxy path prefix for the federation member `id`
+func managedClusterPath(basePath string, id string) string {
+	return basePath + "/fed/cluster/" + id + basePath
+}
+
+// Returns the path of `baseUrl`, without trailing slash
+func getBasePath(baseUrl string) string {
+	u, err := url.Parse(baseUrl)
+
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimRight(u.Path, "/")
+}
+
+// Returns a copy of `c` sending its requests with `ctx`
+//
+// goneuvector.Client stores the context, so concurrent resources
+// sharing the same client would send their requests with each other's one.
+func WithContext(c *goneuvector.Client, ctx context.Context) *goneuvector.Client {
+	ret := *c
+
+	return ret.WithContext(ctx)
+}
+
+// Returns a copy of `c` sending its requests to the federation member `id`
+// through the master proxy. An empty `id` keeps the provider one.
+func WithManagedCluster(c *goneuvector.Client, id string) *goneuvector.Client {
+	ret := *c
+
+	if id == "" {
+		return &ret
+	}
+
+	u, err := url.Parse(c.BaseUrl)
+
+	if err != nil {
+		return &ret
+	}
+
+	u.Path = managedClusterPath(strings.TrimRight(u.Path, "/"), id)
+	ret.BaseUrl = u.String()
+
+	return &ret
+}
+
+// Transport routing the requests through the federation master proxy
+// `/fed/cluster/{id}/...` when the provider targets a managed cluster
+type federationTransport struct {
+	// Next transport in the chain
+	next http.RoundTripper
+	// Base url path, for example `/v1`
+	basePath string
+	// Provider managed cluster ID
+	clusterID string
+}
+
+func newFederationTransport(config *Config, next http.RoundTripper) *federationTransport {
+	return &federationTransport{
+		next:      next,
+		basePath:  getBasePath(config.BaseUrl),
+		clusterID: config.ManagedClusterID,
+	}
+}
+
+func (t *federationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.clusterID == "" || !strings.HasPrefix(req.URL.Path, t.basePath) {
+		return t.next.RoundTrip(req)
+	}
+
+	endpoint := strings.TrimPrefix(req.URL.Path, t.basePath)
+
+	// The federation endpoints are handled by the master itself,
+	// including the proxy used by the resources overriding the cluster
+	if strings.HasPrefix(strings.TrimLeft(endpoint, "/"), "fed/") {
+		return t.next.RoundTrip(req)
+	}
+
+	r := req.Clone(req.Context())
+
+	r.URL.Path = managedClusterPath(t.basePath, t.clusterID) + endpoint
+	r.URL.RawPath = ""
+
+	return t.next.RoundTrip(r)
+}
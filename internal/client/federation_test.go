@@ -0,0 +1,90 @@
+package client_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+)
+
+// Returns a controller recording the requested paths
+func newPathController(t *testing.T) (*testController, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var paths []string
+
+	controller := newTestController(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{}`))
+	})
+
+	return controller, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return paths
+	}
+}
+
+func assertPaths(t *testing.T, paths []string, expected []string) {
+	t.Helper()
+
+	if len(paths) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(paths))
+	}
+
+	for i, path := range paths {
+		if path != expected[i] {
+			t.Fatalf("expected %q, got %q", expected[i], path)
+		}
+	}
+}
+
+func TestManagedClusterProvider(t *testing.T) {
+	controller, paths := newPathController(t)
+
+	config := controller.config()
+	config.ManagedClusterID = "worker"
+
+	c := newTestClient(t, config)
+
+	c.Get("/group", nil)
+	c.Post("/fed/promote", nil, nil)
+	client.WithManagedCluster(c, "other").Get("/group", nil)
+
+	expected := []string{
+		"/v1/fed/cluster/worker/v1//group",
+		"/v1//fed/promote",
+		"/v1/fed/cluster/other/v1//group",
+	}
+
+	assertPaths(t, paths(), expected)
+
+	if controller.loginPath != "/auth" {
+		t.Fatalf("logged in through %q", controller.loginPath)
+	}
+}
+
+func TestManagedClusterResource(t *testing.T) {
+	controller, paths := newPathController(t)
+
+	c := newTestClient(t, controller.config())
+	managed := client.WithManagedCluster(c, "worker")
+
+	managed.Get("/group", nil)
+	managed.Get("/policy/rule", nil)
+	c.Get("/group", nil)
+
+	expected := []string{
+		"/v1/fed/cluster/worker/v1//group",
+		"/v1/fed/cluster/worker/v1//policy/rule",
+		"/v1//group",
+	}
+
+	assertPaths(t, paths(), expected)
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Transport limiting the concurrent requests and their rate,
+// shared by every resource and data source
+type limiterTransport struct {
+	// Next transport in the chain
+	next http.RoundTripper
+	// Concurrent requests slots, nil when unlimited
+	slots chan struct{}
+	// Minimum duration between two requests, 0 when unlimited
+	interval time.Duration
+	// Protects `nextSlot`
+	mu sync.Mutex
+	// Time from which the next request can be sent
+	nextSlot time.Time
+}
+
+func newLimiterTransport(config *Config, next http.RoundTripper) *limiterTransport {
+	t := &limiterTransport{
+		next: next,
+	}
+
+	if config.MaxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+
+	if config.RequestsPerSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / config.RequestsPerSecond)
+	}
+
+	return t
+}
+
+// Wait until the request rate allows a new request
+func (t *limiterTransport) waitRate(ctx context.Context) error {
+	if t.interval == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+
+	now := time.Now()
+	slot := t.nextSlot
+
+	if slot.Before(now) {
+		slot = now
+	}
+
+	t.nextSlot = slot.Add(t.interval)
+
+	t.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(slot))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Take a concurrent request slot, the returned function releases it
+func (t *limiterTransport) acquire(ctx context.Context) (func(), error) {
+	if t.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case t.slots <- struct{}{}:
+	}
+
+	return func() { <-t.slots }, nil
+}
+
+// The slot is released once the response is received, the logging
+// transport below having already read its body. Holding it until the
+// body is closed would deadlock with a session renewal, the caller
+// waiting for the session lock before closing it.
+func (t *limiterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.acquire(req.Context())
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	if err := t.waitRate(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,124 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+)
+
+func TestLimiterConcurrency(t *testing.T) {
+	var mu sync.Mutex
+
+	inFlight, maxInFlight := 0, 0
+
+	controller := newTestController(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		w.Write([]byte(`{}`))
+	})
+
+	config := controller.config()
+	config.MaxConcurrentRequests = 2
+
+	c := newTestClient(t, config)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			client.WithContext(c, context.Background()).Get("/policy/rule", nil)
+		}()
+	}
+
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Fatalf("expected 2 concurrent requests at most, got %d", maxInFlight)
+	}
+}
+
+func TestLimiterRate(t *testing.T) {
+	controller := newTestController(t, nil)
+
+	config := controller.config()
+	config.RequestsPerSecond = 20
+
+	c := newTestClient(t, config)
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		if err := c.Get("/policy/rule", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The login already used the first slot
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("5 requests sent in %s", elapsed)
+	}
+}
+
+// A session renewal waiting for a slot must not block the requests holding them
+func TestLimiterSessionRenewal(t *testing.T) {
+	controller := newTestController(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/slow") {
+			time.Sleep(400 * time.Millisecond)
+		}
+
+		w.Write([]byte(`{}`))
+	})
+	controller.timeout = 1
+
+	config := controller.config()
+	config.MaxConcurrentRequests = 1
+
+	c := newTestClient(t, config)
+	errs := make(chan error, 2)
+
+	// Sent before the renewal margin, answered after it
+	time.Sleep(800 * time.Millisecond)
+
+	go func() {
+		errs <- client.WithContext(c, context.Background()).Get("/slow", nil)
+	}()
+
+	// Renews the session while the slow request holds the slot
+	time.Sleep(150 * time.Millisecond)
+
+	go func() {
+		errs <- client.WithContext(c, context.Background()).Get("/eula", nil)
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("requests still blocked after 5s")
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// Header identifying a request in the logs
+	RequestIDHeader = "X-Request-ID"
+	// Replaces the secret values in the logs
+	redactedValue = "***"
+)
+
+// JSON fields whose values are never logged
+var redactedFields = map[string]bool{
+	"password":       true,
+	"new_password":   true,
+	"auth_token":     true,
+	"token":          true,
+	"secret_key":     true,
+	"secret":         true,
+	"apikey_secret":  true,
+	"private_key":    true,
+	"client_secret":  true,
+	"ssh_passphrase": true,
+}
+
+// Headers whose values are never logged
+var redactedHeaders = []string{
+	TokenHeader,
+	APIKeyHeader,
+	"Authorization",
+}
+
+// Transport logging every request and its response at TRACE level
+type loggingTransport struct {
+	// Next transport in the chain
+	next http.RoundTripper
+	// Context holding the provider logger
+	//
+	// goneuvector.Client sends its requests with context.Background()
+	// after the first one, which doesn't carry the Terraform logger.
+	ctx context.Context
+}
+
+func newLoggingTransport(ctx context.Context, next http.RoundTripper) *loggingTransport {
+	return &loggingTransport{
+		next: next,
+		ctx:  ctx,
+	}
+}
+
+// Returns `v` with the values of the secret fields replaced
+func redactValue(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, field := range value {
+			_, isMap := field.(map[string]any)
+			_, isSlice := field.([]any)
+
+			if redactedFields[k] && !isMap && !isSlice {
+				value[k] = redactedValue
+			} else {
+				value[k] = redactValue(field)
+			}
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+
+	return v
+}
+
+// Returns a JSON body safe to be logged
+func RedactBody(body []byte) string {
+	var v any
+
+	if len(body) == 0 {
+		return ""
+	}
+
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactValue(v))
+
+	if err != nil {
+		return ""
+	}
+
+	return string(redacted)
+}
+
+// Returns headers safe to be logged
+func RedactHeader(header http.Header) map[string]string {
+	ret := map[string]string{}
+
+	for k := range header {
+		ret[k] = header.Get(k)
+	}
+
+	for _, k := range redactedHeaders {
+		if header.Get(k) != "" {
+			ret[http.CanonicalHeaderKey(k)] = redactedValue
+		}
+	}
+
+	return ret
+}
+
+// Returns the context used to log `req`
+func (t *loggingTransport) logContext(req *http.Request) context.Context {
+	if req.Context() == context.Background() {
+		return t.ctx
+	}
+
+	return req.Context()
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+
+	ctx := t.logContext(req)
+	requestID, err := uuid.GenerateUUID()
+
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := cloneRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.Header.Set(RequestIDHeader, requestID)
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	fields := map[string]any{
+		"request_id": requestID,
+		"method":     req.Method,
+		"path":       req.URL.Path,
+	}
+
+	tflog.Trace(ctx, "Sending NeuVector Controller request", map[string]any{
+		"request_id": requestID,
+		"method":     req.Method,
+		"path":       req.URL.Path,
+		"query":      req.URL.RawQuery,
+		"headers":    RedactHeader(r.Header),
+		"body":       RedactBody(reqBody),
+	})
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(r)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Trace(ctx, "NeuVector Controller request failed", fields)
+
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	fields["status"] = resp.StatusCode
+	fields["body"] = RedactBody(respBody)
+
+	tflog.Trace(ctx, "Received NeuVector Controller response", fields)
+
+	return resp, nil
+}
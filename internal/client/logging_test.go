@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+)
+
+func TestRedactBody(t *testing.T) {
+	body := client.RedactBody([]byte(`{"config":{"name":"registry","password":"s3cr3t","auth_token":"t0k3n","filters":["*"]},"token":{"token":"abc","timeout":300}}`))
+	expected := `{"config":{"auth_token":"***","filters":["*"],"name":"registry","password":"***"},"token":{"timeout":300,"token":"***"}}`
+
+	if body != expected {
+		t.Fatalf("expected %s, got %s", expected, body)
+	}
+}
+
+func TestLogging(t *testing.T) {
+	var output bytes.Buffer
+
+	controller := newTestController(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(client.RequestIDHeader) == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte(`{"config":{"secret_key":"k3y"}}`))
+	})
+
+	config := controller.config()
+	config.Password = "s3cr3t"
+
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	c, err := client.NewClient(ctx, config)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Logged with the provider context
+	if err := c.Get("/registry", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := output.String()
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Login request and response, then the registry ones
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 log entries, got %d", len(entries))
+	}
+
+	response := entries[3]
+
+	if response["@level"] != "trace" ||
+		response["method"] != "GET" ||
+		response["path"] != "/v1//registry" ||
+		response["status"] != float64(http.StatusOK) ||
+		response["request_id"] != entries[2]["request_id"] {
+		t.Fatalf("unexpected log entry %v", response)
+	}
+
+	for _, secret := range []string{"s3cr3t", "token-1", "k3y"} {
+		if strings.Contains(logs, secret) {
+			t.Fatalf("%q has been logged", secret)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Returned for every write request when the provider is read-only
+var ErrReadOnly = errors.New("the provider is read-only")
+
+// Transport refusing every request that could modify the controller state
+type readOnlyTransport struct {
+	// Next transport in the chain
+	next http.RoundTripper
+}
+
+func newReadOnlyTransport(next http.RoundTripper) *readOnlyTransport {
+	return &readOnlyTransport{
+		next: next,
+	}
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.next.RoundTrip(req)
+	default:
+		if req.Body != nil {
+			req.Body.Close()
+		}
+
+		return nil, fmt.Errorf("%w, refusing %s %s", ErrReadOnly, req.Method, req.URL.Path)
+	}
+}
@@ -0,0 +1,36 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+)
+
+func TestReadOnly(t *testing.T) {
+	controller, paths := newPathController(t)
+
+	config := controller.config()
+	config.ReadOnly = true
+
+	c := newTestClient(t, config)
+
+	if err := c.Get("/policy/rule", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	writes := []error{
+		c.Post("/group", map[string]any{}, nil),
+		c.Patch("/policy/rule", map[string]any{}, nil),
+		c.Put("/group/test", map[string]any{}, nil),
+		c.Delete("/group/test", nil, nil),
+	}
+
+	for _, err := range writes {
+		if !errors.Is(err, client.ErrReadOnly) {
+			t.Fatalf("expected a read-only error, got %v", err)
+		}
+	}
+
+	assertPaths(t, paths(), []string{"/v1//policy/rule"})
+}
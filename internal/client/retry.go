@@ -0,0 +1,135 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Transport retrying the idempotent requests failing with a transient error
+type retryTransport struct {
+	// Next transport in the chain
+	next http.RoundTripper
+	// Maximum amount of retries
+	maxRetries int
+	// Wait before the first retry
+	minWait time.Duration
+	// Maximum wait between two retries
+	maxWait time.Duration
+}
+
+func newRetryTransport(config *Config, next http.RoundTripper) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: config.MaxRetries,
+		minWait:    config.RetryMinWait,
+		maxWait:    config.RetryMaxWait,
+	}
+}
+
+// Returns if the request can be sent several times without side effects
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// Returns if the failure could be temporary,
+// for example while the controllers elect a new leader
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF)
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= http.StatusInternalServerError
+}
+
+// Returns the duration asked by the `Retry-After` header, if any
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+
+	return 0, false
+}
+
+// Returns the wait before the retry number `attempt`, starting at 0
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	wait := t.minWait
+
+	for i := 0; i < attempt && wait < t.maxWait; i++ {
+		wait *= 2
+	}
+
+	if after, ok := retryAfter(resp); ok && after > wait {
+		wait = after
+	}
+
+	if wait > t.maxWait {
+		wait = t.maxWait
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req.Method) {
+		return t.next.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		r, err := cloneRequest(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(r)
+
+		if attempt >= t.maxRetries || !isRetryable(resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
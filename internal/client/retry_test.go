@@ -0,0 +1,153 @@
+package client_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+)
+
+// Returns a controller answering `statuses` in order, then 200
+func newFlakyController(t *testing.T, statuses ...int) (*testController, func() int) {
+	t.Helper()
+
+	var mu sync.Mutex
+
+	calls := 0
+
+	controller := newTestController(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls++
+
+		if calls <= len(statuses) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(statuses[calls-1])
+			return
+		}
+
+		w.Write([]byte(`{}`))
+	})
+
+	return controller, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return calls
+	}
+}
+
+func retryConfig(controller *testController, maxRetries int) *client.Config {
+	config := controller.config()
+
+	config.MaxRetries = maxRetries
+	config.RetryMinWait = time.Millisecond
+	config.RetryMaxWait = 10 * time.Millisecond
+
+	return config
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	controller, calls := newFlakyController(
+		t,
+		http.StatusServiceUnavailable,
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+	)
+
+	c := newTestClient(t, retryConfig(controller, 3))
+
+	if err := c.Get("/policy/rule", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls() != 4 {
+		t.Fatalf("expected 4 calls, got %d", calls())
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	controller, calls := newFlakyController(
+		t,
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+	)
+
+	c := newTestClient(t, retryConfig(controller, 2))
+
+	if err := c.Patch("/policy/rule", map[string]any{}, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if calls() != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls())
+	}
+}
+
+func TestRetryNotIdempotent(t *testing.T) {
+	controller, calls := newFlakyController(t, http.StatusServiceUnavailable)
+
+	c := newTestClient(t, retryConfig(controller, 3))
+
+	if err := c.Post("/group", map[string]any{}, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if calls() != 1 {
+		t.Fatalf("expected 1 call, got %d", calls())
+	}
+}
+
+func TestRetryClientError(t *testing.T) {
+	controller, calls := newFlakyController(t, http.StatusNotFound)
+
+	c := newTestClient(t, retryConfig(controller, 3))
+
+	if err := c.Delete("/group/test", nil, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if calls() != 1 {
+		t.Fatalf("expected 1 call, got %d", calls())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	var mu sync.Mutex
+	var first time.Time
+
+	calls := 0
+
+	controller := newTestController(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls++
+
+		if calls == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Write([]byte(`{}`))
+	})
+
+	config := retryConfig(controller, 1)
+	config.RetryMaxWait = 2 * time.Second
+
+	c := newTestClient(t, config)
+
+	if err := c.Get("/policy/rule", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(first); elapsed < time.Second {
+		t.Fatalf("Retry-After not honored, retried after %s", elapsed)
+	}
+}
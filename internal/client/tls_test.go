@@ -0,0 +1,152 @@
+package client_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+)
+
+// Returns a self-signed certificate and its key, PEM encoded
+func newTestCertificate(t *testing.T, name string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	return string(certPEM), string(keyPEM)
+}
+
+// Returns a TLS controller using a certificate for `controller.internal`
+// and requiring a client certificate signed by `clientCA` if not empty
+func newTLSTestController(t *testing.T, clientCA string) (*httptest.Server, string) {
+	t.Helper()
+
+	certPEM, keyPEM := newTestCertificate(t, "controller.internal")
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token":{"token":"token"}}`))
+	}))
+
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if clientCA != "" {
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM([]byte(clientCA))
+
+		server.TLS.ClientCAs = pool
+		server.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server, certPEM
+}
+
+func TestTLSCACert(t *testing.T) {
+	server, caPEM := newTLSTestController(t, "")
+
+	config := &client.Config{
+		BaseUrl:       server.URL + "/v1",
+		CACertPEM:     caPEM,
+		TLSServerName: "controller.internal",
+	}
+
+	c := newTestClient(t, config)
+
+	if err := c.Get("/eula", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The certificate is not valid for the server IP address
+	config.TLSServerName = ""
+
+	if _, err := client.NewClient(context.Background(), config); err == nil {
+		t.Fatal("expected a certificate error")
+	}
+}
+
+func TestTLSUnknownCA(t *testing.T) {
+	server, _ := newTLSTestController(t, "")
+	otherPEM, _ := newTestCertificate(t, "controller.internal")
+
+	_, err := client.NewClient(context.Background(), &client.Config{
+		BaseUrl:       server.URL + "/v1",
+		CACertPEM:     otherPEM,
+		TLSServerName: "controller.internal",
+	})
+
+	if err == nil {
+		t.Fatal("expected a certificate error")
+	}
+}
+
+func TestTLSClientCert(t *testing.T) {
+	clientCertPEM, clientKeyPEM := newTestCertificate(t, "terraform")
+	server, caPEM := newTLSTestController(t, clientCertPEM)
+
+	config := &client.Config{
+		BaseUrl:       server.URL + "/v1",
+		CACertPEM:     caPEM,
+		TLSServerName: "controller.internal",
+	}
+
+	if _, err := client.NewClient(context.Background(), config); err == nil {
+		t.Fatal("expected a client certificate error")
+	}
+
+	config.ClientCertPEM = clientCertPEM
+	config.ClientKeyPEM = clientKeyPEM
+
+	newTestClient(t, config)
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"fmt"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+// Represents a NeuVector Controller
+//
+// Avoiding every other fields, we only target the version ones
+type controller struct {
+	// Controller ID
+	ID string `json:"id"`
+	// Controller version
+	Version string `json:"version"`
+	// Indicates if the controller is the cluster leader
+	Leader bool `json:"leader"`
+}
+
+// Response type to get every controller
+type getControllersResponse struct {
+	Controllers []controller `json:"controllers"`
+}
+
+// Returns the version of the leader controller
+func GetControllerVersion(c *goneuvector.Client) (string, error) {
+	var ret getControllersResponse
+
+	if err := c.Get("/controller", &ret); err != nil {
+		return "", err
+	}
+
+	if len(ret.Controllers) == 0 {
+		return "", fmt.Errorf("there is no controller")
+	}
+
+	for _, controller := range ret.Controllers {
+		if controller.Leader {
+			return controller.Version, nil
+		}
+	}
+
+	return ret.Controllers[0].Version, nil
+}
@@ -0,0 +1,25 @@
+package client_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+)
+
+func TestGetControllerVersion(t *testing.T) {
+	controller := newTestController(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"controllers":[{"id":"a","version":"v5.1.0"},{"id":"b","version":"v5.2.1","leader":true}]}`))
+	})
+
+	c := newTestClient(t, controller.config())
+	v, err := client.GetControllerVersion(c)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v != "v5.2.1" {
+		t.Fatalf("expected the leader version, got %q", v)
+	}
+}
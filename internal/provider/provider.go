@@ -2,10 +2,15 @@ package provider
 
 import (
 	"context"
+	"os"
+	"time"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
 	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
 )
 
@@ -16,15 +21,22 @@ func Provider() *schema.Provider {
 			"username": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("NEUVECTOR_PUSERNAME", goneuvector.DefaultUsername),
+				DefaultFunc: schema.EnvDefaultFunc("NEUVECTOR_PUSERNAME", nil),
 				Description: "Represents the NeuVector username.",
 			},
 			"password": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("NEUVECTOR_PASSWORD", goneuvector.DefaultPassword),
+				DefaultFunc: schema.EnvDefaultFunc("NEUVECTOR_PASSWORD", nil),
 				Description: "Represents the NeuVector password.",
 			},
+			"auth_server": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("NEUVECTOR_AUTH_SERVER", nil),
+				ConflictsWith: []string{"api_key_name", "api_key_secret"},
+				Description:   "Name of the NeuVector remote authentication server (LDAP, Active Directory, ...) used to log in, `username` is then a user of this server.",
+			},
 			"base_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -34,57 +46,263 @@ func Provider() *schema.Provider {
 			"insecure": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Default:     true,
-				Description: "Skip the TLS verification. Default: `true`.",
+				DefaultFunc: schema.EnvDefaultFunc("NEUVECTOR_INSECURE", nil),
+				Description: "Skip the TLS verification. Default: `false` when a CA certificate is provided, otherwise `true` with a warning.",
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM encoded CA bundle used to verify the Controller certificate.",
+			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEUVECTOR_CA_CERT_FILE", nil),
+				Description: "Path to a PEM encoded CA bundle used to verify the Controller certificate.",
+			},
+			"client_cert_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key_pem"},
+				Description:  "PEM encoded client certificate, for a mutual TLS authentication.",
+			},
+			"client_key_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_cert_pem"},
+				Description:  "PEM encoded client private key.",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Server name verified in the Controller certificate instead of the `base_url` host.",
+			},
+			"api_key_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("NEUVECTOR_API_KEY_NAME", nil),
+				ConflictsWith: []string{"username", "password"},
+				Description:   "Represents the NeuVector API key name, used instead of `username` and `password`.",
+			},
+			"api_key_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("NEUVECTOR_API_KEY_SECRET", nil),
+				ConflictsWith: []string{"username", "password"},
+				Description:   "Represents the NeuVector API key secret.",
+			},
+			"managed_cluster_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEUVECTOR_MANAGED_CLUSTER_ID", nil),
+				Description: "ID of the federation member cluster managed through the master `base_url`, the requests are sent to its `/fed/cluster/{id}` proxy.",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Refuse every request creating, updating or deleting a NeuVector object, only the reads are sent. Default: `false`.",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests sent at the same time to the Controller by every resource and data source, `0` means unlimited. Default: `0`.",
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of requests sent per second to the Controller by every resource and data source, `0` means unlimited. Default: `0`.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of retries for a GET, PATCH or DELETE request failing with a 5xx, 429 or connection reset. Default: `3`.",
+			},
+			"retry_min_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Minimum time to wait in seconds before retrying a request, doubled at each retry. Default: `1`.",
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum time to wait in seconds between two retries, including the one asked by `Retry-After`. Default: `30`.",
 			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
 			// neuvector
-			"neuvector_admission_rule": neuvector.ResourceAdmissionRule(),
-			"neuvector_promote":        neuvector.ResourcePromote(),
-			"neuvector_registry":       neuvector.ResourceRegistry(),
-			"neuvector_policy":         neuvector.ResourcePolicy(),
-			"neuvector_eula":           neuvector.ResourceEULA(),
-			"neuvector_group":          neuvector.ResourceGroup(),
-			"neuvector_user":           neuvector.ResourceUser(),
-			"neuvector_user_role":      neuvector.ResourceUserRole(),
-			"neuvector_service":        neuvector.ResourceService(),
-			"neuvector_service_config": neuvector.ResourceServiceConfig(),
+			"neuvector_admission_rule":    neuvector.ResourceAdmissionRule(),
+			"neuvector_admission_control": neuvector.ResourceAdmissionControl(),
+			"neuvector_promote":           neuvector.ResourcePromote(),
+			"neuvector_registry":          neuvector.ResourceRegistry(),
+			"neuvector_policy":            neuvector.ResourcePolicy(),
+			"neuvector_policy_rule":       neuvector.ResourcePolicyRule(),
+			"neuvector_policy_promotion":  neuvector.ResourcePolicyPromotion(),
+			"neuvector_eula":              neuvector.ResourceEULA(),
+			"neuvector_group":             neuvector.ResourceGroup(),
+			"neuvector_user":              neuvector.ResourceUser(),
+			"neuvector_user_role":         neuvector.ResourceUserRole(),
+			"neuvector_service":           neuvector.ResourceService(),
+			"neuvector_service_config":    neuvector.ResourceServiceConfig(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			// neuvector
-			"neuvector_registry":       neuvector.DataSourceRegistry(),
-			"neuvector_registry_names": neuvector.DataSourceRegistryNames(),
-			"neuvector_policy_ids":     neuvector.DataSourcePolicyIDs(),
-			"neuvector_eula":           neuvector.DataSourceEULA(),
-			"neuvector_group_metadata":    neuvector.DataSourceGroupMetadata(),
+			"neuvector_registry":           neuvector.DataSourceRegistry(),
+			"neuvector_registry_names":     neuvector.DataSourceRegistryNames(),
+			"neuvector_policy_ids":         neuvector.DataSourcePolicyIDs(),
+			"neuvector_policies":           neuvector.DataSourcePolicies(),
+			"neuvector_policy_analysis":    neuvector.DataSourcePolicyAnalysis(),
+			"neuvector_policy_rule_stats":  neuvector.DataSourcePolicyRuleStats(),
+			"neuvector_security_rule_crd":  neuvector.DataSourceSecurityRuleCRD(),
+			"neuvector_eula":               neuvector.DataSourceEULA(),
+			"neuvector_group_metadata":     neuvector.DataSourceGroupMetadata(),
+			"neuvector_controller_version": neuvector.DataSourceControllerVersion(),
 		},
 	}
 
-	provider.ConfigureContextFunc = func(_ context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
-		// Setup the authentication
-		auth := goneuvector.NewClientAuth(
-			d.Get("username").(string),
-			d.Get("password").(string),
-		)
-
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
 		// Configure the API client
-		config := goneuvector.NewClientConfig(auth)
+		config := &client.Config{
+			BaseUrl:               d.Get("base_url").(string),
+			Username:              d.Get("username").(string),
+			Password:              d.Get("password").(string),
+			AuthServer:            d.Get("auth_server").(string),
+			APIKeyName:            d.Get("api_key_name").(string),
+			APIKeySecret:          d.Get("api_key_secret").(string),
+			ManagedClusterID:      d.Get("managed_cluster_id").(string),
+			ReadOnly:              d.Get("read_only").(bool),
+			MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+			RequestsPerSecond:     d.Get("requests_per_second").(float64),
+			MaxRetries:            d.Get("max_retries").(int),
+			RetryMinWait:          time.Duration(d.Get("retry_min_wait").(int)) * time.Second,
+			RetryMaxWait:          time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+		}
+
+		if config.HasAPIKey() && (config.APIKeyName == "" || config.APIKeySecret == "") {
+			return nil, diag.Errorf("api_key_name and api_key_secret must be set together.")
+		}
+
+		if config.HasAPIKey() && config.AuthServer != "" {
+			return nil, diag.Errorf("auth_server cannot be used with an API key.")
+		}
+
+		// Resolved values, `ConflictsWith` ignores the environment
+		if config.HasAPIKey() && (config.Username != "" || config.Password != "") {
+			return nil, diag.Errorf("username and password cannot be used with an API key.")
+		}
+
+		if !config.HasAPIKey() {
+			setDefaultCredentials(config)
+		}
+
+		diags := configureTLS(d, config)
 
-		config.Insecure = d.Get("insecure").(bool)
-		config.BaseUrl = d.Get("base_url").(string)
+		if diags.HasError() {
+			return nil, diags
+		}
 
 		// Get a new client
-		APIClient, err := goneuvector.NewClient(config)
+		APIClient, err := client.NewClient(ctx, config)
+
+		if err != nil {
+			return nil, append(diags, diag.FromErr(err)...)
+		}
+
+		meta := neuvector.NewMeta(APIClient)
+
+		// Used to reject the attributes unsupported by the controller
+		meta.Version, err = getControllerVersion(client.WithContext(APIClient, ctx))
 
 		if err != nil {
-			return nil, diag.FromErr(err)
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unable to detect the NeuVector Controller version",
+				Detail:   err.Error(),
+			})
 		}
 
-		return APIClient, nil
+		return meta, diags
 	}
 
 	return provider
 }
+
+// Returns the parsed version of the controller
+func getControllerVersion(APIClient *goneuvector.Client) (*version.Version, error) {
+	raw, err := client.GetControllerVersion(APIClient)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return version.NewVersion(raw)
+}
+
+// Fill the credentials missing from the configuration and the environment
+func setDefaultCredentials(config *client.Config) {
+	if config.Username == "" {
+		config.Username = goneuvector.DefaultUsername
+	}
+
+	if config.Password == "" {
+		config.Password = goneuvector.DefaultPassword
+	}
+}
+
+// Fill the TLS fields of `config`
+//
+// `insecure` used to default to `true`, it still does when no CA
+// is provided so existing configurations keep working, with a warning.
+func configureTLS(d *schema.ResourceData, config *client.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	config.CACertPEM = d.Get("ca_cert_pem").(string)
+	config.ClientCertPEM = d.Get("client_cert_pem").(string)
+	config.ClientKeyPEM = d.Get("client_key_pem").(string)
+	config.TLSServerName = d.Get("tls_server_name").(string)
+
+	if path := d.Get("ca_cert_file").(string); path != "" {
+		pem, err := os.ReadFile(path)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		config.CACertPEM = string(pem)
+	}
+
+	// Deprecated but the provider has no raw config to tell unset from `false`
+	insecure, ok := d.GetOkExists("insecure")
+
+	switch {
+	case ok:
+		config.Insecure = insecure.(bool)
+	case config.CACertPEM != "":
+		config.Insecure = false
+	default:
+		config.Insecure = true
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The Controller certificate is not verified",
+			Detail:   "`insecure` defaults to `true` when no CA certificate is provided. Set `insecure = false` (or NEUVECTOR_INSECURE=false) to verify it with the system CAs, this will become the default in a future release.",
+		})
+	}
+
+	return diags
+}
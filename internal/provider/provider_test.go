@@ -1,8 +1,12 @@
 package provider_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/theobori/terraform-provider-neuvector/internal/provider"
 	"github.com/theobori/terraform-provider-neuvector/internal/testutils"
 )
 
@@ -11,3 +15,18 @@ func TestProvider(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+func TestProviderAPIKeyConflict(t *testing.T) {
+	t.Setenv("NEUVECTOR_API_KEY_NAME", "name")
+	t.Setenv("NEUVECTOR_API_KEY_SECRET", "secret")
+
+	p := provider.Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]any{
+		"username": "admin",
+		"password": "admin",
+	}))
+
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "cannot be used with an API key") {
+		t.Fatalf("expected a conflict error, got %v", diags)
+	}
+}
@@ -0,0 +1,188 @@
+// admission_options.go
+package neuvector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+// Options of an admission rule criterion
+type AdmissionCriterionOption struct {
+	// Allowed operators
+	Ops []string `json:"ops"`
+}
+
+// Admission criteria supported by the controller
+type AdmissionOptions struct {
+	// Criteria options of each rule type, by criterion name
+	Criteria map[string]map[string]AdmissionCriterionOption
+	// Allowed operators of each custom criterion value type
+	ValueTypes map[string][]string
+}
+
+type admissionCategoryOptions struct {
+	K8sOptions *struct {
+		RuleOptions map[string]AdmissionCriterionOption `json:"rule_options"`
+	} `json:"k8s_options"`
+}
+
+// Response type of the admission options
+type getAdmissionOptionsResponse struct {
+	Options struct {
+		DenyOptions           *admissionCategoryOptions `json:"deny_options"`
+		ExceptionOptions      *admissionCategoryOptions `json:"exception_options"`
+		CustomCriteriaOptions []struct {
+			ValueType string   `json:"valueType"`
+			Ops       []string `json:"ops"`
+		} `json:"custom_criteria_options"`
+	} `json:"admission_options"`
+}
+
+// Returns the admission options of the controller
+func fetchAdmissionOptions(APIClient *goneuvector.Client) (*AdmissionOptions, error) {
+	var resp getAdmissionOptionsResponse
+
+	if err := APIClient.Get("/admission/options", &resp); err != nil {
+		return nil, err
+	}
+
+	ret := &AdmissionOptions{
+		Criteria:   map[string]map[string]AdmissionCriterionOption{},
+		ValueTypes: map[string][]string{},
+	}
+
+	categories := map[string]*admissionCategoryOptions{
+		"deny":      resp.Options.DenyOptions,
+		"exception": resp.Options.ExceptionOptions,
+	}
+
+	for ruleType, category := range categories {
+		if category != nil && category.K8sOptions != nil {
+			ret.Criteria[ruleType] = category.K8sOptions.RuleOptions
+		}
+	}
+
+	for _, option := range resp.Options.CustomCriteriaOptions {
+		ret.ValueTypes[option.ValueType] = option.Ops
+	}
+
+	return ret, nil
+}
+
+// Returns the admission options, fetched once per provider instance
+func (m *Meta) getAdmissionOptions(APIClient *goneuvector.Client) (*AdmissionOptions, error) {
+	m.admissionOptionsMu.Lock()
+	defer m.admissionOptionsMu.Unlock()
+
+	if m.admissionOptions != nil {
+		return m.admissionOptions, nil
+	}
+
+	options, err := fetchAdmissionOptions(APIClient)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m.admissionOptions = options
+
+	return options, nil
+}
+
+// Returns the sorted keys of `m`, quoted
+func quotedKeys[T any](m map[string]T) string {
+	var keys []string
+
+	for k := range m {
+		keys = append(keys, fmt.Sprintf("%q", k))
+	}
+
+	sort.Strings(keys)
+
+	return strings.Join(keys, ", ")
+}
+
+// Returns `values` quoted
+func quotedValues(values []string) string {
+	var ret []string
+
+	for _, value := range values {
+		ret = append(ret, fmt.Sprintf("%q", value))
+	}
+
+	return strings.Join(ret, ", ")
+}
+
+// Returns whether `values` contains `value`
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns an error if the criterion `c` of a `ruleType` rule
+// is not supported by the controller
+//
+// A criterion with a `value_type` is a custom one, its name is free.
+func (o *AdmissionOptions) ValidateCriterion(ruleType string, c goneuvector.AdmissionRuleCriterion) error {
+	if c.ValueType != nil && *c.ValueType != "" {
+		ops, ok := o.ValueTypes[*c.ValueType]
+
+		if !ok {
+			return fmt.Errorf(
+				"invalid value_type %q for the criterion %q, expected one of %s",
+				*c.ValueType,
+				c.Name,
+				quotedKeys(o.ValueTypes),
+			)
+		}
+
+		if !containsString(ops, c.Op) {
+			return fmt.Errorf(
+				"invalid op %q for the %s criterion %q, expected one of %s",
+				c.Op,
+				*c.ValueType,
+				c.Name,
+				quotedValues(ops),
+			)
+		}
+
+		return nil
+	}
+
+	criteria, ok := o.Criteria[ruleType]
+
+	// Unknown rule type, letting the controller decide
+	if !ok {
+		return nil
+	}
+
+	option, ok := criteria[c.Name]
+
+	if !ok {
+		return fmt.Errorf(
+			"invalid criterion name %q for a %s rule, expected one of %s",
+			c.Name,
+			ruleType,
+			quotedKeys(criteria),
+		)
+	}
+
+	if !containsString(option.Ops, c.Op) {
+		return fmt.Errorf(
+			"invalid op %q for the criterion %q, expected one of %s",
+			c.Op,
+			c.Name,
+			quotedValues(option.Ops),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package neuvector_test
+
+import (
+	"strings"
+	"testing"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
+)
+
+func TestAdmissionOptionsValidateCriterion(t *testing.T) {
+	options := neuvector.AdmissionOptions{
+		Criteria: map[string]map[string]neuvector.AdmissionCriterionOption{
+			"deny": {
+				"cveHighCount": {Ops: []string{">=", ">"}},
+				"runAsRoot":    {Ops: []string{"="}},
+			},
+		},
+		ValueTypes: map[string][]string{
+			"string": {"containsAny", "notContainsAny"},
+		},
+	}
+
+	stringType := "string"
+	numberType := "number"
+
+	tests := []struct {
+		name      string
+		ruleType  string
+		criterion goneuvector.AdmissionRuleCriterion
+		expected  string
+	}{
+		{
+			name:      "valid",
+			ruleType:  "deny",
+			criterion: goneuvector.AdmissionRuleCriterion{Name: "cveHighCount", Op: ">="},
+		},
+		{
+			name:      "invalid name",
+			ruleType:  "deny",
+			criterion: goneuvector.AdmissionRuleCriterion{Name: "cveHighCnt", Op: ">="},
+			expected:  `invalid criterion name "cveHighCnt" for a deny rule, expected one of "cveHighCount", "runAsRoot"`,
+		},
+		{
+			name:      "invalid op",
+			ruleType:  "deny",
+			criterion: goneuvector.AdmissionRuleCriterion{Name: "runAsRoot", Op: "!="},
+			expected:  `invalid op "!=" for the criterion "runAsRoot", expected one of "="`,
+		},
+		{
+			name:      "unknown rule type",
+			ruleType:  "exception",
+			criterion: goneuvector.AdmissionRuleCriterion{Name: "namespace", Op: "containsAny"},
+		},
+		{
+			name:      "valid custom criterion",
+			ruleType:  "deny",
+			criterion: goneuvector.AdmissionRuleCriterion{Name: "item.spec.x", Op: "containsAny", ValueType: &stringType},
+		},
+		{
+			name:      "invalid value type",
+			ruleType:  "deny",
+			criterion: goneuvector.AdmissionRuleCriterion{Name: "item.spec.x", Op: "containsAny", ValueType: &numberType},
+			expected:  `invalid value_type "number" for the criterion "item.spec.x", expected one of "string"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := options.ValidateCriterion(test.ruleType, test.criterion)
+
+			if test.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("expected %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// data_source_controller_version.go
+package neuvector
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var dataControllerVersionSchema = map[string]*schema.Schema{
+	"version": {
+		Type:        schema.TypeString,
+		Description: "Version of the NeuVector Controller, detected when the provider is configured.",
+		Computed:    true,
+	},
+}
+
+func DataSourceControllerVersion() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceControllerVersionRead,
+		Schema:      dataControllerVersionSchema,
+	}
+}
+
+func dataSourceControllerVersionRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	v := meta.(*Meta).Version
+
+	if v == nil {
+		return diag.Errorf("The NeuVector Controller version has not been detected.")
+	}
+
+	id, err := uuid.GenerateUUID()
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("version", v.Original())
+
+	return nil
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	goneuvector "github.com/theobori/go-neuvector/neuvector"
 )
 
 var dataEULASchema = map[string]*schema.Schema{
@@ -26,7 +25,7 @@ func DataSourceEULA() *schema.Resource {
 }
 
 func dataSourceEULARead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	eula, err := APIClient.
 		WithContext(ctx).
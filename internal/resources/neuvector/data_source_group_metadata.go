@@ -107,7 +107,7 @@ func addGroupMetadata(
 func dataSourceGroupMetadataRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	infos := map[string][]string{}
 
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	name := d.Get("name").(string)
 	group, err := APIClient.
@@ -0,0 +1,256 @@
+// data_source_policies.go
+package neuvector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+// Rule fields usable by a filter
+var policyFilterFields = []string{
+	"policy_id",
+	"comment",
+	"from",
+	"to",
+	"ports",
+	"action",
+	"applications",
+	"learned",
+	"disable",
+	"priority",
+	"cfg_type",
+}
+
+// Returns the computed schema of a complete policy rule
+func getDataPolicyRuleSchema() map[string]*schema.Schema {
+	ret := map[string]*schema.Schema{
+		"created_timestamp": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Creation time of the rule, as a Unix timestamp.",
+		},
+		"last_modified_timestamp": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Last modification time of the rule, as a Unix timestamp.",
+		},
+	}
+
+	for k, s := range resourcePolicyRuleSchema {
+		ret[k] = &schema.Schema{
+			Type:        s.Type,
+			Elem:        s.Elem,
+			Computed:    true,
+			Description: s.Description,
+		}
+	}
+
+	ret["applications"].Elem = &schema.Schema{Type: schema.TypeString}
+
+	return ret
+}
+
+var dataPoliciesSchema = map[string]*schema.Schema{
+	"rules_scope": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: policyScopeDescription,
+		Default:     DefaultScope,
+	},
+	"match": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "all",
+		Description:  "Keep the rules matching `all` the filters or `any` of them.",
+		ValidateFunc: validation.StringInSlice([]string{"all", "any"}, false),
+	},
+	"filter": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Filter applied to the rules, every rule is returned without filter.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"field": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "Name of the filtered rule field, a rule matches an `applications` filter if one of its applications does.",
+					ValidateFunc: validation.StringInSlice(policyFilterFields, false),
+				},
+				"operator": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "exact",
+					Description:  "How the field is compared to `value`, `exact`, `contains` or `regex`.",
+					ValidateFunc: validation.StringInSlice([]string{"exact", "contains", "regex"}, false),
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Compared value, or regular expression.",
+				},
+			},
+		},
+	},
+	"rules": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Rules matching the filters, in the evaluation order.",
+		Elem: &schema.Resource{
+			Schema: getDataPolicyRuleSchema(),
+		},
+	},
+}
+
+func DataSourcePolicies() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePoliciesRead,
+		Schema:      dataPoliciesSchema,
+	}
+}
+
+// Filter applied to the policy rules
+type PolicyFilter struct {
+	// Rule field name
+	field string
+	// Comparison operator
+	operator string
+	// Compared value
+	value string
+	// Compiled value for the `regex` operator
+	regex *regexp.Regexp
+}
+
+// Read the filters of the data source
+func ReadPolicyFilters(filtersRaw []any) ([]PolicyFilter, error) {
+	var ret []PolicyFilter
+
+	for _, filterRaw := range filtersRaw {
+		_map := filterRaw.(map[string]any)
+		filter := PolicyFilter{
+			field:    _map["field"].(string),
+			operator: _map["operator"].(string),
+			value:    _map["value"].(string),
+		}
+
+		if filter.operator == "regex" {
+			regex, err := regexp.Compile(filter.value)
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q: %s", filter.value, err)
+			}
+
+			filter.regex = regex
+		}
+
+		ret = append(ret, filter)
+	}
+
+	return ret, nil
+}
+
+// Returns whether `value` matches the filter
+func (f *PolicyFilter) matchValue(value string) bool {
+	switch f.operator {
+	case "contains":
+		return strings.Contains(value, f.value)
+	case "regex":
+		return f.regex.MatchString(value)
+	default:
+		return value == f.value
+	}
+}
+
+// Returns whether the rule `p` matches the filter
+func (f *PolicyFilter) match(p *goneuvector.PolicyRule) bool {
+	rule := GetPolicyRuleMap(p)
+
+	if rule == nil {
+		return false
+	}
+
+	value := (*rule)[f.field]
+
+	if applications, ok := value.([]string); ok {
+		for _, application := range applications {
+			if f.matchValue(application) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return f.matchValue(fmt.Sprint(value))
+}
+
+// Returns whether the rule `p` matches every filter if `all` is true,
+// or at least one of them otherwise
+func MatchPolicyFilters(p *goneuvector.PolicyRule, filters []PolicyFilter, all bool) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	for _, filter := range filters {
+		if filter.match(p) != all {
+			return !all
+		}
+	}
+
+	return all
+}
+
+func dataSourcePoliciesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var rules []map[string]any
+
+	APIClient := getAPIClient(ctx, meta)
+
+	filters, err := ReadPolicyFilters(d.Get("filter").([]any))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policies, err := getPolicies(APIClient, d.Get("rules_scope").(string))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	all := d.Get("match").(string) == "all"
+
+	for _, p := range policies.Rules {
+		if !MatchPolicyFilters(&p, filters, all) {
+			continue
+		}
+
+		rule := GetPolicyRuleMap(&p)
+
+		if rule == nil {
+			continue
+		}
+
+		(*rule)["created_timestamp"] = p.CreatedTimestamp
+		(*rule)["last_modified_timestamp"] = p.LastModifiedTimestamp
+
+		rules = append(rules, *rule)
+	}
+
+	id, err := uuid.GenerateUUID()
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("rules", rules)
+
+	return nil
+}
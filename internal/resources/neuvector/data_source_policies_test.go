@@ -0,0 +1,144 @@
+package neuvector_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/testutils"
+)
+
+func TestAccDataSourcePolicies(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExampleFile(t, "data-sources/neuvector_policies/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.neuvector_policies.test", "rules.#"),
+					resource.TestCheckResourceAttrSet("data.neuvector_policies.containers_web", "rules.#"),
+					resource.TestCheckResourceAttrSet("data.neuvector_policies.learned_or_disabled", "rules.#"),
+				),
+			},
+		},
+	})
+}
+
+func policyFilters(t *testing.T, filters ...map[string]any) []neuvector.PolicyFilter {
+	t.Helper()
+
+	var raw []any
+
+	for _, filter := range filters {
+		if _, ok := filter["operator"]; !ok {
+			filter["operator"] = "exact"
+		}
+
+		raw = append(raw, filter)
+	}
+
+	ret, err := neuvector.ReadPolicyFilters(raw)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ret
+}
+
+func TestMatchPolicyFilters(t *testing.T) {
+	rule := goneuvector.PolicyRule{
+		ID:           1,
+		From:         "containers",
+		To:           "nodes",
+		Ports:        "tcp/80",
+		Action:       "allow",
+		Applications: []string{"HTTP", "SSL"},
+	}
+
+	tests := []struct {
+		name     string
+		filters  []neuvector.PolicyFilter
+		all      bool
+		expected bool
+	}{
+		{
+			name:     "no filter",
+			all:      true,
+			expected: true,
+		},
+		{
+			name:     "exact",
+			filters:  policyFilters(t, map[string]any{"field": "from", "value": "containers"}),
+			all:      true,
+			expected: true,
+		},
+		{
+			name:    "exact mismatch",
+			filters: policyFilters(t, map[string]any{"field": "from", "value": "container"}),
+			all:     true,
+		},
+		{
+			name:     "contains",
+			filters:  policyFilters(t, map[string]any{"field": "ports", "operator": "contains", "value": "80"}),
+			all:      true,
+			expected: true,
+		},
+		{
+			name:     "regex on one of the applications",
+			filters:  policyFilters(t, map[string]any{"field": "applications", "operator": "regex", "value": "^SS."}),
+			all:      true,
+			expected: true,
+		},
+		{
+			name:     "number field",
+			filters:  policyFilters(t, map[string]any{"field": "policy_id", "value": "1"}),
+			all:      true,
+			expected: true,
+		},
+		{
+			name: "all with one mismatch",
+			filters: policyFilters(t,
+				map[string]any{"field": "from", "value": "containers"},
+				map[string]any{"field": "action", "value": "deny"},
+			),
+			all: true,
+		},
+		{
+			name: "any with one match",
+			filters: policyFilters(t,
+				map[string]any{"field": "from", "value": "nodes"},
+				map[string]any{"field": "action", "value": "allow"},
+			),
+			expected: true,
+		},
+		{
+			name: "any without match",
+			filters: policyFilters(t,
+				map[string]any{"field": "from", "value": "nodes"},
+				map[string]any{"field": "applications", "operator": "contains", "value": "DNS"},
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := neuvector.MatchPolicyFilters(&rule, test.filters, test.all)
+
+			if actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReadPolicyFiltersInvalidRegex(t *testing.T) {
+	_, err := neuvector.ReadPolicyFilters([]any{
+		map[string]any{"field": "from", "operator": "regex", "value": "("},
+	})
+
+	if err == nil {
+		t.Fatal("expected an invalid regular expression error")
+	}
+}
@@ -0,0 +1,161 @@
+// data_source_policy_analysis.go
+package neuvector
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+// Returns the schema of the findings,
+// `otherKey` being the name of the earlier rule ID
+func getPolicyFindingSchema(description string, otherKey string, otherDescription string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"policy_id": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the reported rule.",
+				},
+				otherKey: {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: otherDescription,
+				},
+			},
+		},
+	}
+}
+
+var dataPolicyAnalysisSchema = map[string]*schema.Schema{
+	"rules_scope": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: policyScopeDescription,
+		Default:     DefaultScope,
+	},
+	"rule": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Planned rules analyzed with the current ones, a rule with the ID of a current one replaces it. The ones without ID are reported with the IDs -1, -2, etc.",
+		Elem: &schema.Resource{
+			Schema: resourcePolicyRuleSchema,
+		},
+	},
+	"position": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "bottom",
+		Description:  "Place the new planned rules at the `top` or at the `bottom` of the current ones.",
+		ValidateFunc: validation.StringInSlice([]string{"top", "bottom"}, false),
+	},
+	"shadowed": getPolicyFindingSchema(
+		"Rules never matched because an earlier rule matches all of their connections.",
+		"shadowed_by",
+		"ID of the earlier rule.",
+	),
+	"duplicates": getPolicyFindingSchema(
+		"Rules identical to an earlier rule.",
+		"duplicate_of",
+		"ID of the earlier rule.",
+	),
+	"conflicts": getPolicyFindingSchema(
+		"Rules partially overlapping an earlier rule with the opposite action.",
+		"conflicts_with",
+		"ID of the earlier rule.",
+	),
+}
+
+func DataSourcePolicyAnalysis() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePolicyAnalysisRead,
+		Schema:      dataPolicyAnalysisSchema,
+	}
+}
+
+// Returns the current rules with the planned ones
+func mergePlannedPolicyRules(current []goneuvector.PolicyRule, planned []goneuvector.PolicyRule, top bool) []goneuvector.PolicyRule {
+	var added []goneuvector.PolicyRule
+
+	ret := append([]goneuvector.PolicyRule{}, current...)
+	dynamicID := 0
+
+	for _, p := range planned {
+		if p.ID == DynamicPolicyID {
+			dynamicID--
+			p.ID = dynamicID
+		}
+
+		replaced := false
+
+		for i := range ret {
+			if ret[i].ID == p.ID {
+				ret[i] = p
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			added = append(added, p)
+		}
+	}
+
+	if top {
+		return append(added, ret...)
+	}
+
+	return append(ret, added...)
+}
+
+// Returns the findings as Terraform values
+func getPolicyFindings(findings []PolicyFinding, otherKey string) []map[string]any {
+	var ret []map[string]any
+
+	for _, finding := range findings {
+		ret = append(ret, map[string]any{
+			"policy_id": finding.ID,
+			otherKey:    finding.OtherID,
+		})
+	}
+
+	return ret
+}
+
+func dataSourcePolicyAnalysisRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	APIClient := getAPIClient(ctx, meta)
+
+	policies, err := getPolicies(APIClient, d.Get("rules_scope").(string))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rules := mergePlannedPolicyRules(
+		policies.Rules,
+		readPolicyRules(d.Get("rule").([]any)),
+		d.Get("position").(string) == "top",
+	)
+	analysis := AnalyzePolicyRules(rules)
+
+	id, err := uuid.GenerateUUID()
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("shadowed", getPolicyFindings(analysis.Shadowed, "shadowed_by"))
+	d.Set("duplicates", getPolicyFindings(analysis.Duplicates, "duplicate_of"))
+	d.Set("conflicts", getPolicyFindings(analysis.Conflicts, "conflicts_with"))
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package neuvector_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/theobori/terraform-provider-neuvector/internal/testutils"
+)
+
+func TestAccDataSourcePolicyAnalysis(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExampleFile(t, "data-sources/neuvector_policy_analysis/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.neuvector_policy_analysis.test", "shadowed.#"),
+					resource.TestCheckResourceAttrSet("data.neuvector_policy_analysis.test", "duplicates.#"),
+					resource.TestCheckResourceAttrSet("data.neuvector_policy_analysis.test", "conflicts.#"),
+				),
+			},
+		},
+	})
+}
@@ -68,6 +68,12 @@ var dataPolicyIDsSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Used to filter. The type of configuration, its scope, for example whether the rule applies to the whole federation or just to the cluster.",
 	},
+	"rules_scope": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: policyScopeDescription,
+		Default:     DefaultScope,
+	},
 }
 
 func DataSourcePolicyIDs() *schema.Resource {
@@ -78,13 +84,11 @@ func DataSourcePolicyIDs() *schema.Resource {
 }
 
 func dataSourcePolicyIDsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	var ids []int
 
-	policies, err := APIClient.
-		WithContext(ctx).
-		GetPolicies()
+	policies, err := getPolicies(APIClient, d.Get("rules_scope").(string))
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -0,0 +1,95 @@
+// data_source_policy_rule_stats.go
+package neuvector
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/theobori/terraform-provider-neuvector/internal/helper"
+)
+
+var dataPolicyRuleStatsSchema = map[string]*schema.Schema{
+	"policy_ids": {
+		Type:        schema.TypeList,
+		Required:    true,
+		MinItems:    1,
+		Description: "IDs of the rules, for example the `policy_ids` of a `neuvector_policy` resource.",
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+	},
+	"stats": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Hit statistics of the rules, in the `policy_ids` order, computed from the conversations currently known by the controller.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"policy_id": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Rule ID.",
+				},
+				"hit_count": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Number of sessions matched by the rule.",
+				},
+				"bytes": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Number of bytes sent by the matched sessions.",
+				},
+				"last_hit_timestamp": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Last time the rule was matched, as a Unix timestamp, `0` if it never was.",
+				},
+			},
+		},
+	},
+}
+
+func DataSourcePolicyRuleStats() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePolicyRuleStatsRead,
+		Schema:      dataPolicyRuleStatsSchema,
+	}
+}
+
+func dataSourcePolicyRuleStatsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var stats []map[string]any
+
+	APIClient := getAPIClient(ctx, meta)
+
+	ids, err := helper.FromSlice[int](d.Get("policy_ids").([]any))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	entries, err := getConversationEntries(APIClient)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, s := range AggregatePolicyRuleStats(entries, ids) {
+		stats = append(stats, map[string]any{
+			"policy_id":          s.ID,
+			"hit_count":          s.Sessions,
+			"bytes":              s.Bytes,
+			"last_hit_timestamp": s.LastHitTimestamp,
+		})
+	}
+
+	id, err := uuid.GenerateUUID()
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("stats", stats)
+
+	return nil
+}
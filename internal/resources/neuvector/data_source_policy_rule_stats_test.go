@@ -0,0 +1,23 @@
+package neuvector_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/theobori/terraform-provider-neuvector/internal/testutils"
+)
+
+func TestAccDataSourcePolicyRuleStats(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExampleFile(t, "data-sources/neuvector_policy_rule_stats/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.neuvector_policy_rule_stats.test", "stats.#", "1"),
+					resource.TestCheckResourceAttrSet("data.neuvector_policy_rule_stats.test", "stats.0.hit_count"),
+				),
+			},
+		},
+	})
+}
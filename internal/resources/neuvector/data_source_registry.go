@@ -6,7 +6,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	goneuvector "github.com/theobori/go-neuvector/neuvector"
 	"github.com/theobori/terraform-provider-neuvector/internal/helper"
 )
 
@@ -185,7 +184,7 @@ func DataSourceRegistry() *schema.Resource {
 }
 
 func dataSourceRegistryRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	name := d.Get("name").(string)
 	registrySummary, err := APIClient.
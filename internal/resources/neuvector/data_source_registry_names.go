@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	goneuvector "github.com/theobori/go-neuvector/neuvector"
 )
 
 var dataRegistryNamesSchema = map[string]*schema.Schema{
@@ -34,7 +33,7 @@ func DataSourceRegistryNames() *schema.Resource {
 func dataSourceRegistryNamesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	var names []string
 
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	registriesSummaries, err := APIClient.
 		WithContext(ctx).
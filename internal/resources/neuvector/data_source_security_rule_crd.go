@@ -0,0 +1,137 @@
+// data_source_security_rule_crd.go
+package neuvector
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+var dataSecurityRuleCRDSchema = map[string]*schema.Schema{
+	"groups": {
+		Type:        schema.TypeList,
+		Required:    true,
+		MinItems:    1,
+		Description: "Names of the exported groups.",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+	"include_process": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Export the process rules of the groups. Default: `true`.",
+	},
+	"yaml": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "`NvSecurityRule` and `NvClusterSecurityRule` documents, one per group.",
+	},
+}
+
+func DataSourceSecurityRuleCRD() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSecurityRuleCRDRead,
+		Schema:      dataSecurityRuleCRDSchema,
+	}
+}
+
+// Returns the process rules of a group, empty if it has no process profile
+func getProcessRules(APIClient *goneuvector.Client, name string) ([]ProcessRule, error) {
+	var ret getProcessProfileResponse
+
+	if err := APIClient.Get("/process_profile/"+name, &ret); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return ret.Profile.ProcessList, nil
+}
+
+// Returns the criteria of the groups referenced by the rules of `groups`
+func getSecurityRulePeers(
+	APIClient *goneuvector.Client,
+	groups []SecurityRuleGroup,
+) (map[string][]goneuvector.GroupCriteria, error) {
+	ret := map[string][]goneuvector.GroupCriteria{}
+
+	for _, g := range groups {
+		ret[g.Group.Name] = g.Group.Criteria
+	}
+
+	for _, g := range groups {
+		for _, p := range g.Group.PolicyRules {
+			for _, name := range []string{p.From, p.To} {
+				if _, ok := ret[name]; ok {
+					continue
+				}
+
+				peer, err := APIClient.GetGroup(name)
+
+				if err != nil {
+					return nil, err
+				}
+
+				ret[name] = peer.Group.Criteria
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func dataSourceSecurityRuleCRDRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var groups []SecurityRuleGroup
+
+	APIClient := getAPIClient(ctx, meta)
+
+	for _, name := range d.Get("groups").([]any) {
+		group, err := APIClient.GetGroup(name.(string))
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		g := SecurityRuleGroup{
+			Group: group.Group,
+		}
+
+		if d.Get("include_process").(bool) {
+			g.Processes, err = getProcessRules(APIClient, g.Group.Name)
+
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		groups = append(groups, g)
+	}
+
+	peers, err := getSecurityRulePeers(APIClient, groups)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	yaml, err := RenderSecurityRules(groups, peers)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := uuid.GenerateUUID()
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("yaml", yaml)
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package neuvector_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/testutils"
+)
+
+func TestAccDataSourceSecurityRuleCRD(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExampleFile(t, "data-sources/neuvector_security_rule_crd/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.neuvector_security_rule_crd.test", "yaml", regexp.MustCompile("kind: NvClusterSecurityRule")),
+					resource.TestMatchResourceAttr("data.neuvector_security_rule_crd.network_only", "yaml", regexp.MustCompile("process: \\[\\]")),
+				),
+			},
+		},
+	})
+}
+
+func TestGetProcessRules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The client joins the endpoints with an extra slash
+		switch path.Clean(r.URL.Path) {
+		case "/v1/auth":
+			w.Write([]byte(`{"token": {"token": "token", "timeout": 300}}`))
+		case "/v1/process_profile/nv.web.demo":
+			w.Write([]byte(`{
+				"process_profile": {
+					"group": "nv.web.demo",
+					"process_list": [
+						{"name": "nginx", "path": "/usr/sbin/nginx", "action": "allow"}
+					]
+				}
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	APIClient, err := client.NewClient(context.Background(), &client.Config{
+		BaseUrl:  server.URL + "/v1",
+		Username: "admin",
+		Password: "admin",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		group    string
+		expected []neuvector.ProcessRule
+	}{
+		{
+			name:  "process profile",
+			group: "nv.web.demo",
+			expected: []neuvector.ProcessRule{
+				{Name: "nginx", Path: "/usr/sbin/nginx", Action: "allow"},
+			},
+		},
+		{
+			name:     "group without process profile",
+			group:    "external",
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules, err := neuvector.GetProcessRules(APIClient, test.group)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(rules, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, rules)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package neuvector
+
+// Unexported helpers exposed to the neuvector_test package
+var (
+	GetPolicyAnchor      = getPolicyAnchor
+	GetPolicyBlockAnchor = getPolicyBlockAnchor
+	IsPolicyBlockPlaced  = isPolicyBlockPlaced
+	SortPolicyIDs        = sortPolicyIDs
+	ReadPolicyPlacement  = readPolicyPlacement
+	GetProcessRules      = getProcessRules
+)
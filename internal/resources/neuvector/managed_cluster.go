@@ -0,0 +1,29 @@
+// managed_cluster.go
+package neuvector
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+)
+
+// Schema of the attribute routing a resource to a federation member
+var managedClusterIDSchema = &schema.Schema{
+	Type:        schema.TypeString,
+	Optional:    true,
+	ForceNew:    true,
+	Description: "ID of the federation member cluster managing this resource through the master, overrides the provider `managed_cluster_id`.",
+}
+
+// Returns the API client bound to `ctx`,
+// sending its requests to the resource managed cluster if any
+func getManagedClusterClient(ctx context.Context, d *schema.ResourceData, meta any) *goneuvector.Client {
+	APIClient := client.WithManagedCluster(
+		meta.(*Meta).Client,
+		d.Get("managed_cluster_id").(string),
+	)
+
+	return APIClient.WithContext(ctx)
+}
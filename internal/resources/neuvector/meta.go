@@ -0,0 +1,113 @@
+// meta.go
+package neuvector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+)
+
+// Provider meta shared by every resource and data source
+type Meta struct {
+	// API client
+	Client *goneuvector.Client
+	// Controller version, nil if it could not be detected
+	Version *version.Version
+	// Dynamic policy IDs allocator
+	PolicyIDs *PolicyIDAllocator
+
+	// Admission options catalogue, nil until fetched
+	admissionOptions   *AdmissionOptions
+	admissionOptionsMu sync.Mutex
+}
+
+func NewMeta(APIClient *goneuvector.Client) *Meta {
+	return &Meta{
+		Client:    APIClient,
+		PolicyIDs: NewPolicyIDAllocator(),
+	}
+}
+
+// Returns the API client bound to `ctx`
+func getAPIClient(ctx context.Context, meta any) *goneuvector.Client {
+	return client.WithContext(meta.(*Meta).Client, ctx)
+}
+
+// Returns whether `err` is the controller answer for a missing object
+func isNotFound(err error) bool {
+	var apiErr *goneuvector.APIError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Returns whether the controller is at least at the version `minimum`,
+// true when its version is unknown
+func (m *Meta) supports(minimum string) bool {
+	if m.Version == nil {
+		return true
+	}
+
+	return !m.Version.LessThan(version.Must(version.NewVersion(minimum)))
+}
+
+// Returns whether `attribute` is set in the configuration
+func isConfigured(d *schema.ResourceData, attribute string) bool {
+	config := d.GetRawConfig()
+
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+
+	return !config.GetAttr(attribute).IsNull()
+}
+
+// Minimum controller version supporting an attribute
+type versionRequirement struct {
+	// Attribute name
+	attribute string
+	// Minimum controller version
+	version string
+}
+
+// Returns a CustomizeDiff function rejecting the configured attributes
+// that the controller is too old to support
+func customizeDiffVersion(requirements ...versionRequirement) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta any) error {
+		m, ok := meta.(*Meta)
+
+		// Unknown version, letting the controller decide
+		if !ok || m.Version == nil {
+			return nil
+		}
+
+		config := d.GetRawConfig()
+
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+
+		for _, requirement := range requirements {
+			if config.GetAttr(requirement.attribute).IsNull() {
+				continue
+			}
+
+			if !m.supports(requirement.version) {
+				return fmt.Errorf(
+					"`%s` requires a NeuVector Controller %s or later, the current one is %s",
+					requirement.attribute,
+					requirement.version,
+					m.Version.Original(),
+				)
+			}
+		}
+
+		return nil
+	}
+}
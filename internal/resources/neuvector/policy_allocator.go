@@ -0,0 +1,143 @@
+// policy_allocator.go
+package neuvector
+
+import (
+	"fmt"
+	"sync"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+// Allocates the dynamic policy IDs of every resource of the provider
+//
+// The allocations are serialized per scope and the allocated IDs are
+// reserved until their patch completes, so resources created in parallel
+// never pick the same ones.
+type PolicyIDAllocator struct {
+	mu sync.Mutex
+	// Lock of each scope
+	scopes map[string]*sync.Mutex
+	// IDs of the patches in progress
+	reserved map[int]bool
+}
+
+func NewPolicyIDAllocator() *PolicyIDAllocator {
+	return &PolicyIDAllocator{
+		scopes:   map[string]*sync.Mutex{},
+		reserved: map[int]bool{},
+	}
+}
+
+// Returns the lock of the scope `scopeName`
+func (a *PolicyIDAllocator) scopeLock(scopeName string) *sync.Mutex {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	lock, ok := a.scopes[scopeName]
+
+	if !ok {
+		lock = &sync.Mutex{}
+		a.scopes[scopeName] = lock
+	}
+
+	return lock
+}
+
+// Reserves `amount` IDs free on the controller
+func (a *PolicyIDAllocator) allocate(APIClient *goneuvector.Client, scopeName string, amount int) ([]int, error) {
+	var ret []int
+
+	if amount == 0 {
+		return ret, nil
+	}
+
+	params := GetScopeChanges(scopeName)
+	lock := a.scopeLock(scopeName)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	policies, err := getPolicies(APIClient, scopeName)
+
+	if err != nil {
+		return ret, err
+	}
+
+	used := map[int]bool{}
+
+	for _, id := range policies.GetPolicyIDs() {
+		used[id] = true
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for id := params.minID; len(ret) < amount && id < params.maxID; id++ {
+		if used[id] || a.reserved[id] {
+			continue
+		}
+
+		ret = append(ret, id)
+	}
+
+	if len(ret) < amount {
+		return nil, fmt.Errorf("there are not enough available policy IDS")
+	}
+
+	for _, id := range ret {
+		a.reserved[id] = true
+	}
+
+	return ret, nil
+}
+
+// Releases the reserved `ids`
+//
+// Waiting for the allocations in progress, which could otherwise
+// have listed the policies before the patch and see them free.
+func (a *PolicyIDAllocator) release(scopeName string, ids []int) {
+	lock := a.scopeLock(scopeName)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, id := range ids {
+		delete(a.reserved, id)
+	}
+}
+
+// Allocates the IDs of the dynamic rules of `body` then patches the policy
+//
+// The controller overwrites the rule of an existing ID instead of refusing
+// it, so the IDs stay reserved until the patch completes.
+func (a *PolicyIDAllocator) Patch(APIClient *goneuvector.Client, body *goneuvector.PatchPolicyBody, scopeName string) error {
+	// Get the dynamic rules index in body.Rules
+	// Used to determinate the amount of need available index
+	indexes := GetDynamicPolicyIndexes(&body.Rules)
+	params := GetScopeChanges(scopeName)
+
+	policyIDs, err := a.allocate(APIClient, scopeName, len(indexes))
+
+	if err != nil {
+		return err
+	}
+
+	// Updating the body with the new IDs if needed
+	for i, index := range indexes {
+		body.Rules[index].ID = policyIDs[i]
+	}
+
+	err = APIClient.PatchPolicy(*body, params.IsFed)
+	a.release(scopeName, policyIDs)
+
+	if err != nil {
+		for _, index := range indexes {
+			body.Rules[index].ID = DynamicPolicyID
+		}
+	}
+
+	return err
+}
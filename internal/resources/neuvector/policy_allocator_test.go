@@ -0,0 +1,147 @@
+package neuvector_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/client"
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
+)
+
+// Fake controller storing the policy rules,
+// overwriting the rules whose ID is already taken like the real one
+func newPolicyController(t *testing.T) (*httptest.Server, func() []int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var ids []int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v1")
+
+		if strings.HasPrefix(path, "/auth") {
+			w.Write([]byte(`{"token": {"token": "token", "timeout": 300}}`))
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodGet {
+			var rules []goneuvector.PolicyRule
+
+			for _, id := range ids {
+				rules = append(rules, goneuvector.PolicyRule{ID: id})
+			}
+
+			json.NewEncoder(w).Encode(goneuvector.GetPoliciesResponse{Rules: rules})
+			return
+		}
+
+		var body goneuvector.PatchPolicyBody
+
+		json.NewDecoder(r.Body).Decode(&body)
+
+		// Leaves time to the other requests
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+
+		for _, rule := range body.Rules {
+			found := false
+
+			for _, id := range ids {
+				if id == rule.ID {
+					found = true
+					break
+				}
+			}
+
+			// An overwritten rule is lost, lowering the rules count
+			if !found {
+				ids = append(ids, rule.ID)
+			}
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]int{}, ids...)
+	}
+}
+
+func TestPolicyIDAllocatorParallel(t *testing.T) {
+	server, getIDs := newPolicyController(t)
+
+	APIClient, err := client.NewClient(context.Background(), &client.Config{
+		BaseUrl:  server.URL + "/v1",
+		Username: "admin",
+		Password: "admin",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allocator := neuvector.NewPolicyIDAllocator()
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 8)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			body := goneuvector.PatchPolicyBody{
+				Rules: []goneuvector.PolicyRule{
+					{ID: neuvector.DynamicPolicyID},
+					{ID: neuvector.DynamicPolicyID},
+				},
+			}
+
+			errs <- allocator.Patch(
+				client.WithContext(APIClient, context.Background()),
+				&body,
+				neuvector.DefaultScope,
+			)
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := getIDs()
+	seen := map[int]bool{}
+
+	for _, id := range ids {
+		if seen[id] {
+			t.Fatalf("the ID %d has been allocated twice", id)
+		}
+
+		seen[id] = true
+	}
+
+	if len(ids) != 16 {
+		t.Fatalf("expected 16 rules, got %d", len(ids))
+	}
+}
@@ -0,0 +1,296 @@
+// policy_analysis.go
+package neuvector
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+// Value matching every group and application
+const policyAny = "any"
+
+// Highest port number
+const policyMaxPort = 65535
+
+// Ports range of a protocol
+type policyPortRange struct {
+	protocol string
+	min      int
+	max      int
+}
+
+// Returns the ports ranges of a ports string, merged per protocol,
+// nil if the ports are invalid
+func getPolicyPortRanges(s string) []policyPortRange {
+	var ranges []policyPortRange
+
+	ports, err := ParsePolicyPorts(s)
+
+	if err != nil {
+		return nil
+	}
+
+	for _, item := range ports {
+		switch item {
+		case "any":
+			ranges = append(
+				ranges,
+				policyPortRange{"tcp", 0, policyMaxPort},
+				policyPortRange{"udp", 0, policyMaxPort},
+				policyPortRange{"icmp", 0, 0},
+			)
+			continue
+		case "icmp":
+			ranges = append(ranges, policyPortRange{"icmp", 0, 0})
+			continue
+		}
+
+		protocols := []string{"tcp", "udp"}
+		protocol, portRange, ok := strings.Cut(item, "/")
+
+		if ok {
+			protocols = []string{protocol}
+		} else {
+			portRange = protocol
+		}
+
+		low, high := 0, policyMaxPort
+
+		if portRange != "any" {
+			bounds := strings.Split(portRange, "-")
+			low, _ = strconv.Atoi(bounds[0])
+			high = low
+
+			if len(bounds) == 2 {
+				high, _ = strconv.Atoi(bounds[1])
+			}
+		}
+
+		for _, protocol := range protocols {
+			ranges = append(ranges, policyPortRange{protocol, low, high})
+		}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].protocol != ranges[j].protocol {
+			return ranges[i].protocol < ranges[j].protocol
+		}
+
+		return ranges[i].min < ranges[j].min
+	})
+
+	var ret []policyPortRange
+
+	for _, r := range ranges {
+		last := len(ret) - 1
+
+		if last >= 0 && ret[last].protocol == r.protocol && r.min <= ret[last].max+1 {
+			if r.max > ret[last].max {
+				ret[last].max = r.max
+			}
+
+			continue
+		}
+
+		ret = append(ret, r)
+	}
+
+	return ret
+}
+
+// Returns whether the ports `a` include every port of `b`
+func policyPortsCover(a []policyPortRange, b []policyPortRange) bool {
+	if len(b) == 0 {
+		return false
+	}
+
+	for _, rb := range b {
+		covered := false
+
+		for _, ra := range a {
+			if ra.protocol == rb.protocol && ra.min <= rb.min && rb.max <= ra.max {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns whether the ports `a` and `b` have a port in common
+func policyPortsOverlap(a []policyPortRange, b []policyPortRange) bool {
+	for _, ra := range a {
+		for _, rb := range b {
+			if ra.protocol == rb.protocol && ra.min <= rb.max && rb.min <= ra.max {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Returns whether the applications contain "any", or are empty
+func hasAnyApplication(applications []string) bool {
+	for _, application := range applications {
+		if strings.EqualFold(application, policyAny) {
+			return true
+		}
+	}
+
+	return len(applications) == 0
+}
+
+// Returns whether the applications `a` include every application of `b`
+func policyApplicationsCover(a []string, b []string) bool {
+	if hasAnyApplication(a) {
+		return true
+	}
+
+	if hasAnyApplication(b) {
+		return false
+	}
+
+	for _, application := range b {
+		found := false
+
+		for _, other := range a {
+			if strings.EqualFold(application, other) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns whether the applications `a` and `b` have one in common
+func policyApplicationsOverlap(a []string, b []string) bool {
+	if hasAnyApplication(a) || hasAnyApplication(b) {
+		return true
+	}
+
+	for _, application := range a {
+		for _, other := range b {
+			if strings.EqualFold(application, other) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Returns whether the group `a` includes the group `b`
+func policyGroupCovers(a string, b string) bool {
+	return a == policyAny || a == b
+}
+
+// Returns whether the groups `a` and `b` have an endpoint in common
+func policyGroupsOverlap(a string, b string) bool {
+	return policyGroupCovers(a, b) || policyGroupCovers(b, a)
+}
+
+// Returns whether the rule `a` matches every connection of the rule `b`
+func policyRuleCovers(a *goneuvector.PolicyRule, b *goneuvector.PolicyRule) bool {
+	return policyGroupCovers(a.From, b.From) &&
+		policyGroupCovers(a.To, b.To) &&
+		policyPortsCover(getPolicyPortRanges(a.Ports), getPolicyPortRanges(b.Ports)) &&
+		policyApplicationsCover(a.Applications, b.Applications)
+}
+
+// Returns whether the rules `a` and `b` match a connection in common
+func policyRulesOverlap(a *goneuvector.PolicyRule, b *goneuvector.PolicyRule) bool {
+	return policyGroupsOverlap(a.From, b.From) &&
+		policyGroupsOverlap(a.To, b.To) &&
+		policyPortsOverlap(getPolicyPortRanges(a.Ports), getPolicyPortRanges(b.Ports)) &&
+		policyApplicationsOverlap(a.Applications, b.Applications)
+}
+
+// Returns whether the rules `a` and `b` are the same
+func policyRulesEqual(a *goneuvector.PolicyRule, b *goneuvector.PolicyRule) bool {
+	return a.Action == b.Action &&
+		policyRuleCovers(a, b) &&
+		policyRuleCovers(b, a)
+}
+
+// Finding about a rule, compared to an earlier one
+type PolicyFinding struct {
+	// Analyzed rule ID
+	ID int
+	// Earlier rule ID
+	OtherID int
+}
+
+// Findings of the policy analysis
+type PolicyAnalysis struct {
+	// Rules never matched because an earlier rule matches their connections
+	Shadowed []PolicyFinding
+	// Rules identical to an earlier rule
+	Duplicates []PolicyFinding
+	// Rules partially overlapping an earlier rule with another action
+	Conflicts []PolicyFinding
+}
+
+// Analyzes the rules in their evaluation order, the disabled ones are ignored
+//
+// Each rule is reported once, as a duplicate, a shadowed
+// or a conflicting rule, against the first earlier rule concerned.
+func AnalyzePolicyRules(rules []goneuvector.PolicyRule) PolicyAnalysis {
+	var ret PolicyAnalysis
+
+	for i := range rules {
+		rule := &rules[i]
+
+		if rule.Disable {
+			continue
+		}
+
+		var conflict *PolicyFinding
+
+		for j := 0; j < i; j++ {
+			earlier := &rules[j]
+
+			if earlier.Disable {
+				continue
+			}
+
+			finding := PolicyFinding{rule.ID, earlier.ID}
+
+			if policyRulesEqual(earlier, rule) {
+				ret.Duplicates = append(ret.Duplicates, finding)
+				conflict = nil
+				break
+			}
+
+			if policyRuleCovers(earlier, rule) {
+				ret.Shadowed = append(ret.Shadowed, finding)
+				conflict = nil
+				break
+			}
+
+			if conflict == nil && earlier.Action != rule.Action && policyRulesOverlap(earlier, rule) {
+				conflict = &finding
+			}
+		}
+
+		if conflict != nil {
+			ret.Conflicts = append(ret.Conflicts, *conflict)
+		}
+	}
+
+	return ret
+}
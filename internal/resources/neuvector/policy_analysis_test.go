@@ -0,0 +1,111 @@
+package neuvector_test
+
+import (
+	"reflect"
+	"testing"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
+)
+
+func policyRule(id int, from string, to string, ports string, action string, applications ...string) goneuvector.PolicyRule {
+	return goneuvector.PolicyRule{
+		ID:           id,
+		From:         from,
+		To:           to,
+		Ports:        ports,
+		Action:       action,
+		Applications: applications,
+	}
+}
+
+func TestAnalyzePolicyRules(t *testing.T) {
+	disabled := policyRule(1, "any", "any", "any", "deny", "any")
+	disabled.Disable = true
+
+	tests := []struct {
+		name     string
+		rules    []goneuvector.PolicyRule
+		expected neuvector.PolicyAnalysis
+	}{
+		{
+			name: "shadowed by any to any",
+			rules: []goneuvector.PolicyRule{
+				policyRule(1, "any", "any", "any", "deny", "any"),
+				policyRule(2, "containers", "nodes", "tcp/80", "allow", "HTTP"),
+			},
+			expected: neuvector.PolicyAnalysis{
+				Shadowed: []neuvector.PolicyFinding{{ID: 2, OtherID: 1}},
+			},
+		},
+		{
+			name: "disabled rules are ignored",
+			rules: []goneuvector.PolicyRule{
+				disabled,
+				policyRule(2, "containers", "nodes", "tcp/80", "allow", "HTTP"),
+			},
+		},
+		{
+			name: "duplicate",
+			rules: []goneuvector.PolicyRule{
+				policyRule(1, "containers", "nodes", "TCP/80", "allow", "HTTP", "SSL"),
+				policyRule(2, "containers", "nodes", "tcp/80", "allow", "ssl", "http"),
+			},
+			expected: neuvector.PolicyAnalysis{
+				Duplicates: []neuvector.PolicyFinding{{ID: 2, OtherID: 1}},
+			},
+		},
+		{
+			name: "partial overlap with another action",
+			rules: []goneuvector.PolicyRule{
+				policyRule(1, "containers", "nodes", "tcp/80-90", "allow", "any"),
+				policyRule(2, "containers", "nodes", "tcp/85-100", "deny", "any"),
+				policyRule(3, "containers", "nodes", "udp/85", "deny", "any"),
+			},
+			expected: neuvector.PolicyAnalysis{
+				Conflicts: []neuvector.PolicyFinding{{ID: 2, OtherID: 1}},
+			},
+		},
+		{
+			name: "ports without protocol",
+			rules: []goneuvector.PolicyRule{
+				policyRule(1, "containers", "nodes", "tcp/80", "allow", "any"),
+				policyRule(2, "containers", "nodes", "80", "allow", "any"),
+				policyRule(3, "containers", "nodes", "udp/80", "allow", "any"),
+			},
+			expected: neuvector.PolicyAnalysis{
+				Shadowed: []neuvector.PolicyFinding{{ID: 3, OtherID: 2}},
+			},
+		},
+		{
+			name: "merged port ranges",
+			rules: []goneuvector.PolicyRule{
+				policyRule(1, "containers", "nodes", "tcp/80-85,tcp/86-90", "deny", "any"),
+				policyRule(2, "containers", "nodes", "tcp/82-88", "allow", "HTTP"),
+			},
+			expected: neuvector.PolicyAnalysis{
+				Shadowed: []neuvector.PolicyFinding{{ID: 2, OtherID: 1}},
+			},
+		},
+		{
+			name: "applications",
+			rules: []goneuvector.PolicyRule{
+				policyRule(1, "containers", "nodes", "any", "allow", "HTTP"),
+				policyRule(2, "containers", "nodes", "any", "deny", "any"),
+				policyRule(3, "containers", "nodes", "any", "deny", "Redis"),
+			},
+			expected: neuvector.PolicyAnalysis{
+				Conflicts: []neuvector.PolicyFinding{{ID: 2, OtherID: 1}},
+				Shadowed:  []neuvector.PolicyFinding{{ID: 3, OtherID: 2}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		analysis := neuvector.AnalyzePolicyRules(test.rules)
+
+		if !reflect.DeepEqual(analysis, test.expected) {
+			t.Fatalf("%s: expected %+v, got %+v", test.name, test.expected, analysis)
+		}
+	}
+}
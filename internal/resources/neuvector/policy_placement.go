@@ -0,0 +1,222 @@
+// policy_placement.go
+package neuvector
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+const (
+	// `after` value placing a rule at the top of the policy
+	policyTop = 0
+	// `after` value placing a rule at the bottom of the policy
+	policyBottom = -1
+)
+
+// Returns the index of `id` in `ids`, -1 if missing
+func indexOfPolicyID(ids []int, id int) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Returns `current` without `ids`
+func excludePolicyIDs(current []int, ids []int) []int {
+	var ret []int
+
+	for _, id := range current {
+		if indexOfPolicyID(ids, id) == -1 {
+			ret = append(ret, id)
+		}
+	}
+
+	return ret
+}
+
+// Returns the `after` value our rules `ids` must follow in `current`,
+// and whether a placement has been configured
+func getPolicyAnchor(d *schema.ResourceData, current []int, ids []int) (int, bool, error) {
+	others := excludePolicyIDs(current, ids)
+
+	if v, ok := d.GetOk("insert_after_id"); ok {
+		id := v.(int)
+
+		if indexOfPolicyID(others, id) == -1 {
+			return 0, true, fmt.Errorf("the policy rule %d doesn't exist", id)
+		}
+
+		return id, true, nil
+	}
+
+	if v, ok := d.GetOk("insert_before_id"); ok {
+		id := v.(int)
+		i := indexOfPolicyID(others, id)
+
+		if i == -1 {
+			return 0, true, fmt.Errorf("the policy rule %d doesn't exist", id)
+		}
+
+		if i == 0 {
+			return policyTop, true, nil
+		}
+
+		return others[i-1], true, nil
+	}
+
+	switch d.Get("position").(string) {
+	case "top":
+		return policyTop, true, nil
+	case "bottom":
+		return policyBottom, true, nil
+	}
+
+	return 0, false, nil
+}
+
+// Returns whether `ids` directly follow `after` in `current`, in order
+func isPolicyBlockPlaced(current []int, ids []int, after int) bool {
+	var start int
+
+	switch after {
+	case policyTop:
+		start = 0
+	case policyBottom:
+		start = len(current) - len(ids)
+	default:
+		start = indexOfPolicyID(current, after) + 1
+
+		if start == 0 {
+			return false
+		}
+	}
+
+	if start < 0 || start+len(ids) > len(current) {
+		return false
+	}
+
+	return reflect.DeepEqual(current[start:start+len(ids)], ids)
+}
+
+// Returns `ids` sorted by their position in `current`
+func sortPolicyIDs(current []int, ids []int) []int {
+	var ret []int
+
+	for _, id := range current {
+		if indexOfPolicyID(ids, id) != -1 {
+			ret = append(ret, id)
+		}
+	}
+
+	return ret
+}
+
+// Returns the `after` value keeping the rules `ids` where the first of them is
+func getPolicyBlockAnchor(current []int, ids []int) int {
+	for i, id := range current {
+		if indexOfPolicyID(ids, id) == -1 {
+			continue
+		}
+
+		if i > 0 {
+			return current[i-1]
+		}
+
+		break
+	}
+
+	return policyTop
+}
+
+// Moves the rules `ids` where the resource places them
+//
+// The ordered rules are kept in the configuration order,
+// right after the rule preceding the first of them when no placement is set.
+func placePolicyRules(APIClient *goneuvector.Client, d *schema.ResourceData, ids []int, scopeName string) error {
+	_, ordered := d.GetOk("ordered_rule")
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	policies, err := getPolicies(APIClient, scopeName)
+
+	if err != nil {
+		return err
+	}
+
+	current := policies.GetPolicyIDs()
+	after, ok, err := getPolicyAnchor(d, current, ids)
+
+	if err != nil {
+		return err
+	}
+
+	if !ok && !ordered {
+		return nil
+	}
+
+	if !ordered {
+		ids = sortPolicyIDs(current, ids)
+	}
+
+	if !ok {
+		after = getPolicyBlockAnchor(current, ids)
+	}
+
+	if isPolicyBlockPlaced(current, ids, after) {
+		return nil
+	}
+
+	for i, id := range ids {
+		if i > 0 {
+			after = ids[i-1]
+		}
+
+		err = APIClient.PatchPolicy(
+			goneuvector.PatchPolicyBody{
+				Move: &goneuvector.PolicyRuleMove{
+					After: after,
+					ID:    id,
+				},
+			},
+			GetScopeChanges(scopeName).IsFed,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reports a misplaced rules block as drift, by resetting its placement
+//
+// The order inside the block is already part of the ordered rules.
+func readPolicyPlacement(d *schema.ResourceData, current []int, ids []int) {
+	if len(ids) == 0 {
+		return
+	}
+
+	ids = sortPolicyIDs(current, ids)
+	after, ok, err := getPolicyAnchor(d, current, ids)
+
+	if !ok {
+		return
+	}
+
+	if err == nil && isPolicyBlockPlaced(current, ids, after) {
+		return
+	}
+
+	d.Set("insert_after_id", nil)
+	d.Set("insert_before_id", nil)
+	d.Set("position", nil)
+}
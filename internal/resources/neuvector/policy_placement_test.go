@@ -0,0 +1,206 @@
+package neuvector_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
+)
+
+func policyPlacementData(t *testing.T, raw map[string]any) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, neuvector.ResourcePolicy().Schema, raw)
+}
+
+func TestGetPolicyAnchor(t *testing.T) {
+	current := []int{1, 2, 3, 4}
+	ids := []int{3}
+
+	tests := []struct {
+		name       string
+		raw        map[string]any
+		after      int
+		configured bool
+		err        bool
+	}{
+		{
+			name: "no placement",
+			raw:  map[string]any{},
+		},
+		{
+			name:       "top",
+			raw:        map[string]any{"position": "top"},
+			after:      0,
+			configured: true,
+		},
+		{
+			name:       "bottom",
+			raw:        map[string]any{"position": "bottom"},
+			after:      -1,
+			configured: true,
+		},
+		{
+			name:       "after",
+			raw:        map[string]any{"insert_after_id": 4},
+			after:      4,
+			configured: true,
+		},
+		{
+			name:       "before",
+			raw:        map[string]any{"insert_before_id": 2},
+			after:      1,
+			configured: true,
+		},
+		{
+			name:       "before the first rule",
+			raw:        map[string]any{"insert_before_id": 1},
+			after:      0,
+			configured: true,
+		},
+		{
+			name:       "missing anchor",
+			raw:        map[string]any{"insert_after_id": 9},
+			configured: true,
+			err:        true,
+		},
+		{
+			name:       "anchored on a managed rule",
+			raw:        map[string]any{"insert_before_id": 3},
+			configured: true,
+			err:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := policyPlacementData(t, test.raw)
+			after, configured, err := neuvector.GetPolicyAnchor(d, current, ids)
+
+			if (err != nil) != test.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err == nil && after != test.after {
+				t.Errorf("expected after %d, got %d", test.after, after)
+			}
+
+			if configured != test.configured {
+				t.Errorf("expected configured %t, got %t", test.configured, configured)
+			}
+		})
+	}
+}
+
+func TestIsPolicyBlockPlaced(t *testing.T) {
+	current := []int{1, 2, 3, 4}
+
+	tests := []struct {
+		name     string
+		ids      []int
+		after    int
+		expected bool
+	}{
+		{"top", []int{1, 2}, 0, true},
+		{"not at the top", []int{2, 3}, 0, false},
+		{"bottom", []int{3, 4}, -1, true},
+		{"not at the bottom", []int{2, 3}, -1, false},
+		{"after a rule", []int{3, 4}, 2, true},
+		{"wrong order", []int{4, 3}, 2, false},
+		{"missing anchor", []int{3}, 9, false},
+		{"overflowing block", []int{4, 5}, 3, false},
+		{"more rules than the policy", []int{1, 2, 3, 4, 5}, -1, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := neuvector.IsPolicyBlockPlaced(current, test.ids, test.after)
+
+			if actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSortPolicyIDs(t *testing.T) {
+	actual := neuvector.SortPolicyIDs([]int{5, 1, 4, 2}, []int{2, 4, 5, 9})
+
+	if expected := []int{5, 4, 2}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestGetPolicyBlockAnchor(t *testing.T) {
+	current := []int{1, 2, 3, 4}
+
+	tests := []struct {
+		name     string
+		ids      []int
+		expected int
+	}{
+		{"first rule", []int{1, 3}, 0},
+		{"middle", []int{3, 2}, 1},
+		{"last rule", []int{4}, 3},
+		{"missing rules", []int{9}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := neuvector.GetPolicyBlockAnchor(current, test.ids)
+
+			if actual != test.expected {
+				t.Errorf("expected %d, got %d", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReadPolicyPlacement(t *testing.T) {
+	current := []int{1, 2, 3, 4}
+
+	tests := []struct {
+		name     string
+		raw      map[string]any
+		ids      []int
+		expected map[string]any
+	}{
+		{
+			name:     "placed",
+			raw:      map[string]any{"insert_after_id": 2},
+			ids:      []int{3},
+			expected: map[string]any{"insert_after_id": 2, "insert_before_id": 0, "position": ""},
+		},
+		{
+			name:     "misplaced",
+			raw:      map[string]any{"position": "top"},
+			ids:      []int{3, 4},
+			expected: map[string]any{"insert_after_id": 0, "insert_before_id": 0, "position": ""},
+		},
+		{
+			name:     "placed at the bottom, in any order",
+			raw:      map[string]any{"position": "bottom"},
+			ids:      []int{4, 3},
+			expected: map[string]any{"insert_after_id": 0, "insert_before_id": 0, "position": "bottom"},
+		},
+		{
+			name:     "missing anchor",
+			raw:      map[string]any{"insert_before_id": 9},
+			ids:      []int{3},
+			expected: map[string]any{"insert_after_id": 0, "insert_before_id": 0, "position": ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := policyPlacementData(t, test.raw)
+
+			neuvector.ReadPolicyPlacement(d, current, test.ids)
+
+			for k, v := range test.expected {
+				if actual := d.Get(k); actual != v {
+					t.Errorf("expected %s = %v, got %v", k, v, actual)
+				}
+			}
+		})
+	}
+}
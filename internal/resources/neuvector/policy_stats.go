@@ -0,0 +1,109 @@
+// policy_stats.go
+package neuvector
+
+import (
+	"fmt"
+	"net/url"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+// Traffic matched by a policy rule between two endpoints
+type ConversationEntry struct {
+	PolicyID   int   `json:"policy_id"`
+	Sessions   int   `json:"sessions"`
+	Bytes      int   `json:"bytes"`
+	LastSeenAt int64 `json:"last_seen_at"`
+}
+
+// Conversation between two endpoints
+type conversation struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Response type of the conversations list
+type getConversationsResponse struct {
+	Conversations []conversation `json:"conversations"`
+}
+
+// Response type of the conversation between two endpoints
+type getConversationResponse struct {
+	Conversation struct {
+		Entries []ConversationEntry `json:"entries"`
+	} `json:"conversation"`
+}
+
+// Hit statistics of a policy rule
+type PolicyRuleStats struct {
+	// Policy rule ID
+	ID int
+	// Number of matched sessions
+	Sessions int
+	// Number of bytes sent by the matched sessions
+	Bytes int
+	// Last time the rule was matched, as a Unix timestamp, 0 if never
+	LastHitTimestamp int64
+}
+
+// Returns the statistics of the rules `ids` from the conversation entries,
+// in the same order
+func AggregatePolicyRuleStats(entries []ConversationEntry, ids []int) []PolicyRuleStats {
+	stats := map[int]*PolicyRuleStats{}
+	ret := make([]PolicyRuleStats, len(ids))
+
+	for i, id := range ids {
+		ret[i].ID = id
+		stats[id] = &ret[i]
+	}
+
+	for _, entry := range entries {
+		s, ok := stats[entry.PolicyID]
+
+		if !ok {
+			continue
+		}
+
+		s.Sessions += entry.Sessions
+		s.Bytes += entry.Bytes
+
+		if entry.LastSeenAt > s.LastHitTimestamp {
+			s.LastHitTimestamp = entry.LastSeenAt
+		}
+	}
+
+	return ret
+}
+
+// Returns the entries of every conversation known by the controller
+func getConversationEntries(APIClient *goneuvector.Client) ([]ConversationEntry, error) {
+	var conversations getConversationsResponse
+	var ret []ConversationEntry
+
+	if err := APIClient.Get("/conversation", &conversations); err != nil {
+		return nil, err
+	}
+
+	for _, c := range conversations.Conversations {
+		var detail getConversationResponse
+
+		endpoint := fmt.Sprintf(
+			"/conversation/%s/%s",
+			url.PathEscape(c.From),
+			url.PathEscape(c.To),
+		)
+
+		if err := APIClient.Get(endpoint, &detail); err != nil {
+			// The conversation expired in the meantime
+			if isNotFound(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		ret = append(ret, detail.Conversation.Entries...)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,29 @@
+package neuvector_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
+)
+
+func TestAggregatePolicyRuleStats(t *testing.T) {
+	entries := []neuvector.ConversationEntry{
+		{PolicyID: 1, Sessions: 2, Bytes: 100, LastSeenAt: 1686000000},
+		{PolicyID: 1, Sessions: 3, Bytes: 50, LastSeenAt: 1686000500},
+		{PolicyID: 2, Sessions: 1, Bytes: 10, LastSeenAt: 1685000000},
+		{PolicyID: 4, Sessions: 7, Bytes: 70, LastSeenAt: 1687000000},
+	}
+
+	expected := []neuvector.PolicyRuleStats{
+		{ID: 3},
+		{ID: 1, Sessions: 5, Bytes: 150, LastHitTimestamp: 1686000500},
+		{ID: 2, Sessions: 1, Bytes: 10, LastHitTimestamp: 1685000000},
+	}
+
+	actual := neuvector.AggregatePolicyRuleStats(entries, []int{3, 1, 2})
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,171 @@
+// policy_validation.go
+package neuvector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Applications NeuVector understands, "any" selecting everything
+var policyApplications = []string{
+	"any",
+	"ActiveMQ",
+	"Apache",
+	"Cassandra",
+	"Consul",
+	"Couchbase",
+	"DHCP",
+	"DNS",
+	"ECHO",
+	"ElasticSearch",
+	"Etcd",
+	"gRPC",
+	"HTTP",
+	"HTTPS",
+	"Jetty",
+	"Kafka",
+	"Memcached",
+	"MongoDB",
+	"MSSQL",
+	"MySQL",
+	"Nginx",
+	"NodeJS",
+	"NTP",
+	"Oracle",
+	"PostgreSQL",
+	"RabbitMQ",
+	"Radius",
+	"Redis",
+	"RTSP",
+	"SIP",
+	"Spark",
+	"SSH",
+	"SSL",
+	"Syslog",
+	"TFTP",
+	"VoltDB",
+	"Zookeeper",
+}
+
+// Parse a port or a port range, for example `80` or `6142-6150`
+func parsePolicyPortRange(s string) (string, error) {
+	bounds := strings.Split(s, "-")
+
+	if len(bounds) > 2 {
+		return "", fmt.Errorf("invalid port range %q", s)
+	}
+
+	var ports []int
+
+	for _, bound := range bounds {
+		port, err := strconv.Atoi(bound)
+
+		if err != nil || port < 0 || port > 65535 {
+			return "", fmt.Errorf("invalid port %q", bound)
+		}
+
+		ports = append(ports, port)
+	}
+
+	if len(ports) == 2 && ports[0] > ports[1] {
+		return "", fmt.Errorf("invalid port range %q", s)
+	}
+
+	return s, nil
+}
+
+// Parse the ports of a policy rule then returns them normalized
+//
+// The grammar is a comma separated list of `any`, `icmp`,
+// or a port or a port range optionally prefixed by `tcp/` or `udp/`,
+// these ones also accepting `any`.
+// Sample: 80,tcp/8080,udp/6142-6150,tcp/any,udp/any,icmp,any
+func ParsePolicyPorts(s string) ([]string, error) {
+	var ret []string
+
+	for _, item := range strings.Split(s, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+
+		if item == "any" || item == "icmp" {
+			ret = append(ret, item)
+			continue
+		}
+
+		protocol, ports, ok := strings.Cut(item, "/")
+
+		if !ok {
+			ports, protocol = protocol, ""
+		} else if protocol != "tcp" && protocol != "udp" {
+			return nil, fmt.Errorf("invalid protocol %q, expected tcp or udp", protocol)
+		}
+
+		if protocol != "" && ports == "any" {
+			ret = append(ret, item)
+			continue
+		}
+
+		if _, err := parsePolicyPortRange(ports); err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, item)
+	}
+
+	return ret, nil
+}
+
+// Returns the normalized ports, `s` if they are invalid
+func NormalizePolicyPorts(s string) string {
+	ports, err := ParsePolicyPorts(s)
+
+	if err != nil {
+		return s
+	}
+
+	return strings.Join(ports, ",")
+}
+
+func validatePolicyPorts(v any, k string) ([]string, []error) {
+	if _, err := ParsePolicyPorts(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+
+	return nil, nil
+}
+
+// Returns the catalogue spelling of an application, "" if it is unknown
+func NormalizePolicyApplication(s string) string {
+	for _, application := range policyApplications {
+		if strings.EqualFold(application, s) {
+			return application
+		}
+	}
+
+	return ""
+}
+
+func validatePolicyApplication(v any, k string) ([]string, []error) {
+	if NormalizePolicyApplication(v.(string)) == "" {
+		return nil, []error{
+			fmt.Errorf(
+				"%s: unknown application %q, expected one of %s",
+				k,
+				v,
+				strings.Join(policyApplications, ", "),
+			),
+		}
+	}
+
+	return nil, nil
+}
+
+func diffSuppressPolicyPorts(k, old, new string, d *schema.ResourceData) bool {
+	return NormalizePolicyPorts(old) == NormalizePolicyPorts(new)
+}
+
+func diffSuppressPolicyApplications(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
@@ -0,0 +1,68 @@
+package neuvector_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
+)
+
+func TestParsePolicyPorts(t *testing.T) {
+	valid := map[string]string{
+		"any":                      "any",
+		"ICMP":                     "icmp",
+		"80":                       "80",
+		"TCP/80":                   "tcp/80",
+		"80, tcp/8080":             "80,tcp/8080",
+		"udp/6142-6150":            "udp/6142-6150",
+		"tcp/any,udp/any,icmp,any": "tcp/any,udp/any,icmp,any",
+		"0-65535":                  "0-65535",
+	}
+
+	for ports, expected := range valid {
+		parsed, err := neuvector.ParsePolicyPorts(ports)
+
+		if err != nil {
+			t.Fatalf("%q: %s", ports, err)
+		}
+
+		if strings.Join(parsed, ",") != expected {
+			t.Fatalf("%q: expected %q, got %q", ports, expected, parsed)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"http",
+		"65536",
+		"sctp/80",
+		"tcp/",
+		"any/80",
+		"8080-80",
+		"1-2-3",
+		"80,,443",
+		"-80",
+	}
+
+	for _, ports := range invalid {
+		if _, err := neuvector.ParsePolicyPorts(ports); err == nil {
+			t.Fatalf("%q: expected an error", ports)
+		}
+	}
+}
+
+func TestNormalizePolicyApplication(t *testing.T) {
+	applications := map[string]string{
+		"any":   "any",
+		"http":  "HTTP",
+		"MYSQL": "MySQL",
+		"redis": "Redis",
+		"Bogus": "",
+	}
+
+	for application, expected := range applications {
+		if normalized := neuvector.NormalizePolicyApplication(application); normalized != expected {
+			t.Fatalf("%q: expected %q, got %q", application, expected, normalized)
+		}
+	}
+}
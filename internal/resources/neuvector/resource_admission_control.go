@@ -0,0 +1,313 @@
+// resource_admission_control.go
+package neuvector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+// Namespace NeuVector is usually deployed in
+const defaultNeuVectorNamespace = "neuvector"
+
+var resourceAdmissionControlSchema = map[string]*schema.Schema{
+	"enable": {
+		Type:        schema.TypeBool,
+		Required:    true,
+		Description: "Enable the admission control.",
+	},
+	"mode": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "monitor",
+		Description:  "`monitor` only logs the denied deployments, `protect` rejects them. Default: `monitor`.",
+		ValidateFunc: validation.StringInSlice([]string{"monitor", "protect"}, false),
+	},
+	"default_action": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "allow",
+		Description:  "Action applied to the deployments matching no rule, `allow` or `deny`. Default: `allow`.",
+		ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+	},
+	"client_mode": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "service",
+		Description:  "How the Kubernetes API server reaches the admission webhook, through its `service` or its `url`. Default: `service`.",
+		ValidateFunc: validation.StringInSlice([]string{"service", "url"}, false),
+	},
+	"failure_policy": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "ignore",
+		Description:  "What the Kubernetes API server does when the webhook is unreachable, `ignore` the request or `fail` it. Default: `ignore`.",
+		ValidateFunc: validation.StringInSlice([]string{"ignore", "fail"}, false),
+	},
+	"exempt_neuvector_namespace": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Keep the default rule allowing every deployment in the NeuVector namespace enabled. Default: `true`.",
+	},
+	"neuvector_namespace": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     defaultNeuVectorNamespace,
+		Description: "Namespace NeuVector is deployed in. Default: `neuvector`.",
+	},
+}
+
+func ResourceAdmissionControl() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages the cluster wide admission control state. Destroying this resource disables the admission control for the whole cluster, whatever its state before the creation, and enables the default rule exempting the NeuVector namespace again.",
+		CreateContext: resourceAdmissionControlCreate,
+		ReadContext:   resourceAdmissionControlRead,
+		UpdateContext: resourceAdmissionControlUpdate,
+		DeleteContext: resourceAdmissionControlDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: resourceAdmissionControlSchema,
+	}
+}
+
+// Global state of the admission control
+type AdmissionState struct {
+	Enable        *bool   `json:"enable,omitempty"`
+	Mode          *string `json:"mode,omitempty"`
+	DefaultAction *string `json:"default_action,omitempty"`
+	AdmClientMode *string `json:"adm_client_mode,omitempty"`
+	FailurePolicy *string `json:"failure_policy,omitempty"`
+}
+
+// Body and response type of the admission control state
+type admissionStateFull struct {
+	State AdmissionState `json:"state"`
+}
+
+// Returns the admission control state
+func getAdmissionState(APIClient *goneuvector.Client) (*AdmissionState, error) {
+	var ret admissionStateFull
+
+	if err := APIClient.Get("/admission/state", &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret.State, nil
+}
+
+// Returns the default critical rule allowing the deployments in `namespace`
+func getNamespaceExemptionRule(APIClient *goneuvector.Client, namespace string) (*goneuvector.AdmissionRule, error) {
+	rules, err := APIClient.GetAdmissionRules()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules.Ruleq {
+		if !rule.Critical || rule.RuleType != "exception" {
+			continue
+		}
+
+		for _, criterion := range rule.Criteria {
+			if criterion.Name != "namespace" {
+				continue
+			}
+
+			for _, value := range strings.Split(criterion.Value, ",") {
+				if strings.TrimSpace(value) == namespace {
+					return &rule, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// Enable or disable the default rule exempting the NeuVector namespace
+func patchNamespaceExemption(APIClient *goneuvector.Client, rule *goneuvector.AdmissionRule, exempt bool) error {
+	if rule.Disable == !exempt {
+		return nil
+	}
+
+	body := goneuvector.PatchAdmissionRuleBody{
+		ID:       rule.ID,
+		Category: rule.Category,
+		Comment:  rule.Comment,
+		Criteria: rule.Criteria,
+		Disable:  !exempt,
+		CfgType:  rule.CfgType,
+		RuleType: rule.RuleType,
+	}
+
+	if rule.RuleMode != "" {
+		body.RuleMode = &rule.RuleMode
+	}
+
+	return APIClient.PatchAdmissionRule(body)
+}
+
+// Enable or disable the exemption of `namespace`
+func setNamespaceExemption(APIClient *goneuvector.Client, namespace string, exempt bool) error {
+	rule, err := getNamespaceExemptionRule(APIClient, namespace)
+
+	if err != nil {
+		return err
+	}
+
+	if rule == nil {
+		if !exempt {
+			return nil
+		}
+
+		return fmt.Errorf("no default admission rule allows the %q namespace", namespace)
+	}
+
+	return patchNamespaceExemption(APIClient, rule, exempt)
+}
+
+// Apply the configured admission control state
+func patchAdmissionControl(APIClient *goneuvector.Client, d *schema.ResourceData) error {
+	enable := d.Get("enable").(bool)
+	mode := d.Get("mode").(string)
+	defaultAction := d.Get("default_action").(string)
+	clientMode := d.Get("client_mode").(string)
+	failurePolicy := d.Get("failure_policy").(string)
+
+	body := admissionStateFull{
+		State: AdmissionState{
+			Enable:        &enable,
+			Mode:          &mode,
+			DefaultAction: &defaultAction,
+			AdmClientMode: &clientMode,
+			FailurePolicy: &failurePolicy,
+		},
+	}
+
+	if err := APIClient.Patch("/admission/state", body, nil); err != nil {
+		return err
+	}
+
+	return setNamespaceExemption(
+		APIClient,
+		d.Get("neuvector_namespace").(string),
+		d.Get("exempt_neuvector_namespace").(bool),
+	)
+}
+
+func resourceAdmissionControlCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	APIClient := getAPIClient(ctx, meta)
+
+	if err := patchAdmissionControl(APIClient, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := uuid.GenerateUUID()
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+
+	return resourceAdmissionControlRead(ctx, d, meta)
+}
+
+func resourceAdmissionControlRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	APIClient := getAPIClient(ctx, meta)
+
+	state, err := getAdmissionState(APIClient)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if state.Enable != nil {
+		d.Set("enable", *state.Enable)
+	}
+
+	if state.Mode != nil {
+		d.Set("mode", *state.Mode)
+	}
+
+	if state.DefaultAction != nil {
+		d.Set("default_action", *state.DefaultAction)
+	}
+
+	if state.AdmClientMode != nil {
+		d.Set("client_mode", *state.AdmClientMode)
+	}
+
+	if state.FailurePolicy != nil {
+		d.Set("failure_policy", *state.FailurePolicy)
+	}
+
+	namespace := d.Get("neuvector_namespace").(string)
+
+	// Imported without the defaults
+	if namespace == "" {
+		namespace = defaultNeuVectorNamespace
+		d.Set("neuvector_namespace", namespace)
+	}
+
+	rule, err := getNamespaceExemptionRule(APIClient, namespace)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("exempt_neuvector_namespace", rule != nil && !rule.Disable)
+
+	return nil
+}
+
+func resourceAdmissionControlUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	APIClient := getAPIClient(ctx, meta)
+
+	if err := patchAdmissionControl(APIClient, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAdmissionControlRead(ctx, d, meta)
+}
+
+// Disable the admission control and restore the NeuVector namespace exemption
+func resourceAdmissionControlDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	APIClient := getAPIClient(ctx, meta)
+
+	enable := false
+	body := admissionStateFull{
+		State: AdmissionState{
+			Enable: &enable,
+		},
+	}
+
+	if err := APIClient.Patch("/admission/state", body, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule, err := getNamespaceExemptionRule(APIClient, d.Get("neuvector_namespace").(string))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if rule == nil {
+		return nil
+	}
+
+	if err := patchNamespaceExemption(APIClient, rule, true); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
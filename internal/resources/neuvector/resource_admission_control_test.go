@@ -0,0 +1,44 @@
+package neuvector_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/theobori/terraform-provider-neuvector/internal/testutils"
+)
+
+const testAccResourceAdmissionControlUpdated = `
+resource "neuvector_admission_control" "test" {
+  enable         = true
+  mode           = "protect"
+  default_action = "allow"
+  failure_policy = "ignore"
+}
+`
+
+func TestAccResourceAdmissionControl(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExampleFile(t, "resources/neuvector_admission_control/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("neuvector_admission_control.test", "enable", "true"),
+					resource.TestCheckResourceAttr("neuvector_admission_control.test", "mode", "monitor"),
+					resource.TestCheckResourceAttr("neuvector_admission_control.test", "exempt_neuvector_namespace", "true"),
+				),
+			},
+			{
+				Config: testAccResourceAdmissionControlUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("neuvector_admission_control.test", "mode", "protect"),
+				),
+			},
+			{
+				ResourceName:      "neuvector_admission_control.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
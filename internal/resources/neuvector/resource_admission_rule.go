@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	goneuvector "github.com/theobori/go-neuvector/neuvector"
 	"github.com/theobori/terraform-provider-neuvector/internal/helper"
@@ -75,11 +76,13 @@ var resourceAdmissionRuleSchema = map[string]*schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    true,
 		Default:     "user_created",
+		ForceNew:    true,
 		Description: "The type of configuration, its scope, for example whether the rule applies to the whole federation or just to the cluster.",
 	},
 	"rule_type": {
 		Type:        schema.TypeString,
 		Required:    true,
+		ForceNew:    true,
 		Description: "Indicate whether this rule is to \"allow\" this type of connection, or \"deny\" it.",
 	},
 	"rule_mode": {
@@ -98,13 +101,59 @@ func ResourceAdmissionRule() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffVersion(
+				versionRequirement{"rule_mode", "5.0.0"},
+			),
+			customizeDiffAdmissionCriteria,
+		),
 
 		Schema: resourceAdmissionRuleSchema,
 	}
 }
 
+// Rejects the criteria unsupported by the controller admission options
+func customizeDiffAdmissionCriteria(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	m, ok := meta.(*Meta)
+
+	if !ok {
+		return nil
+	}
+
+	config := d.GetRawConfig()
+
+	if config.IsNull() || !config.IsWhollyKnown() {
+		return nil
+	}
+
+	options, err := m.getAdmissionOptions(getAPIClient(ctx, meta))
+
+	// Unknown options, letting the controller decide
+	if err != nil {
+		return nil
+	}
+
+	ruleType := d.Get("rule_type").(string)
+
+	for _, criterionRaw := range d.Get("criteria").(*schema.Set).List() {
+		_map := criterionRaw.(map[string]any)
+		valueType := _map["value_type"].(string)
+		criterion := goneuvector.AdmissionRuleCriterion{
+			Name:      _map["name"].(string),
+			Op:        _map["op"].(string),
+			ValueType: &valueType,
+		}
+
+		if err := options.ValidateCriterion(ruleType, criterion); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func resourceAdmissionRuleCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	criteriasRaw := d.Get("criteria").(*schema.Set).List()
 	criterias := helper.FromTypeSetDefault[goneuvector.AdmissionRuleCriterion](criteriasRaw)
@@ -147,7 +196,7 @@ func getCriteria(criterias []goneuvector.AdmissionRuleCriterion) []map[string]an
 }
 
 func resourceAdmissionRuleRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	id, err := strconv.Atoi(d.Id())
 
@@ -176,26 +225,34 @@ func resourceAdmissionRuleRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceAdmissionRuleUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	if !d.HasChanges(
-		"criteria",
-		"rule_type",
-		"rule_mode",
-		"disable",
-		"category",
-		"cfg_type",
-	) {
-		return nil
+	APIClient := getAPIClient(ctx, meta)
+
+	id, err := strconv.Atoi(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	if diag := resourceAdmissionRuleDelete(ctx, d, meta); diag != nil {
-		return diag
+	criteriasRaw := d.Get("criteria").(*schema.Set).List()
+	criterias := helper.FromTypeSetDefault[goneuvector.AdmissionRuleCriterion](criteriasRaw)
+
+	body := helper.FromSchemas[goneuvector.PatchAdmissionRuleBody](
+		resourceAdmissionRuleSchema,
+		d,
+	)
+
+	body.ID = id
+	body.Criteria = criterias
+
+	if err := APIClient.PatchAdmissionRule(body); err != nil {
+		return diag.FromErr(err)
 	}
 
-	return resourceAdmissionRuleCreate(ctx, d, meta)
+	return resourceAdmissionRuleRead(ctx, d, meta)
 }
 
 func resourceAdmissionRuleDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	var err error
 
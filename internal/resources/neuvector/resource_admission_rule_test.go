@@ -8,9 +8,27 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
 	"github.com/theobori/terraform-provider-neuvector/internal/testutils"
 )
 
+const testAccResourceAdmissionRuleUpdated = `
+resource "neuvector_admission_rule" "test" {
+  rule_type = "deny"
+  category  = "Kubernetes"
+  comment   = "Containers prevention, root only"
+
+  criteria {
+    name  = "runAsRoot"
+    op    = "="
+    path  = "runAsRoot"
+    value = "true"
+  }
+
+  disable = true
+}
+`
+
 func TestAccResourceAdmissionRule(t *testing.T) {
 	var adm goneuvector.AdmissionRule
 
@@ -27,6 +45,15 @@ func TestAccResourceAdmissionRule(t *testing.T) {
 					resource.TestCheckResourceAttrSet("neuvector_admission_rule.test", "cfg_type"),
 				),
 			},
+			{
+				Config: testAccResourceAdmissionRuleUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccAdmissionRuleCheckSameID("neuvector_admission_rule.test", &adm),
+					resource.TestCheckResourceAttr("neuvector_admission_rule.test", "criteria.#", "1"),
+					resource.TestCheckResourceAttr("neuvector_admission_rule.test", "disable", "true"),
+					resource.TestCheckResourceAttr("neuvector_admission_rule.test", "comment", "Containers prevention, root only"),
+				),
+			},
 			{
 				ResourceName:            "neuvector_admission_rule.test",
 				ImportState:             true,
@@ -49,7 +76,7 @@ func testAccAdmissionRuleCheckExists(rn string, adm *goneuvector.AdmissionRule)
 			return fmt.Errorf("resource id not set")
 		}
 
-		APIClient := testutils.Provider.Meta().(*goneuvector.Client)
+		APIClient := testutils.Provider.Meta().(*neuvector.Meta).Client
 		id, err := strconv.Atoi(rs.Primary.ID)
 
 		if err != nil {
@@ -68,9 +95,26 @@ func testAccAdmissionRuleCheckExists(rn string, adm *goneuvector.AdmissionRule)
 	}
 }
 
+// Checks that the rule has been updated in place
+func testAccAdmissionRuleCheckSameID(rn string, adm *goneuvector.AdmissionRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID != strconv.Itoa(adm.ID) {
+			return fmt.Errorf("admission rule recreated, id %d became %s", adm.ID, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
 func testAccAdmissionRuleCheckDestroy(adm *goneuvector.AdmissionRule) resource.TestCheckFunc {
 	return func(_ *terraform.State) error {
-		APIClient := testutils.Provider.Meta().(*goneuvector.Client)
+		APIClient := testutils.Provider.Meta().(*neuvector.Meta).Client
 
 		_, err := APIClient.GetAdmissionRule(adm.ID)
 
@@ -34,7 +34,7 @@ func ResourceEULA() *schema.Resource {
 }
 
 func resourceEULACreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	eula := helper.FromSchemas[goneuvector.EULA](
 		resourceEULASchema,
@@ -57,7 +57,7 @@ func resourceEULACreate(ctx context.Context, d *schema.ResourceData, meta any) d
 }
 
 func resourceEULAUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	eula := helper.FromSchemas[goneuvector.EULA](
 		resourceEULASchema,
@@ -72,7 +72,7 @@ func resourceEULAUpdate(ctx context.Context, d *schema.ResourceData, meta any) d
 }
 
 func resourceEULARead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	eula, err := APIClient.
 		WithContext(ctx).
@@ -88,7 +88,7 @@ func resourceEULARead(ctx context.Context, d *schema.ResourceData, meta any) dia
 }
 
 func resourceEULADelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	eula := helper.FromSchemas[goneuvector.EULA](
 		resourceEULASchema,
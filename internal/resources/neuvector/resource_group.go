@@ -46,6 +46,7 @@ var resourceGroupSchema = map[string]*schema.Schema{
 		Default:     "user_created",
 		Description: "The type of configuration, its scope, for example whether the rule applies to the whole federation or just to the cluster.",
 	},
+	"managed_cluster_id": managedClusterIDSchema,
 }
 
 func ResourceGroup() *schema.Resource {
@@ -91,11 +92,11 @@ func getGroupCriteria(criterias *[]goneuvector.GroupCriteria) []map[string]any {
 }
 
 func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
 	group := readGroup(d)
 
-	if err := APIClient.WithContext(ctx).CreateGroup(*group); err != nil {
+	if err := APIClient.CreateGroup(*group); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -109,11 +110,11 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, meta any)
 		return diag.Errorf("You are not allowed to change the group name.")
 	}
 
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
 	group := readGroup(d)
 
-	if err := APIClient.WithContext(ctx).PatchGroup(group.Name, *group); err != nil {
+	if err := APIClient.PatchGroup(group.Name, *group); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -121,11 +122,9 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, meta any)
 }
 
 func resourceGroupRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
-	groupData, err := APIClient.
-		WithContext(ctx).
-		GetGroup(d.Id())
+	groupData, err := APIClient.GetGroup(d.Id())
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -146,13 +145,11 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, meta any) di
 }
 
 func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
-	err := APIClient.
-		WithContext(ctx).
-		DeleteGroup(
-			d.Get("name").(string),
-		)
+	err := APIClient.DeleteGroup(
+		d.Get("name").(string),
+	)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -3,13 +3,16 @@ package neuvector
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	goneuvector "github.com/theobori/go-neuvector/neuvector"
-	"github.com/theobori/go-neuvector/util"
 	"github.com/theobori/terraform-provider-neuvector/internal/helper"
 )
 
@@ -22,6 +25,8 @@ const (
 	DynamicPolicyID = -1
 	// Default scope
 	DefaultScope = "user_created"
+	// Description of the `rules_scope` attributes
+	policyScopeDescription = "Scope of the rules, `user_created` or `federal`."
 )
 
 var resourcePolicyRuleSchema = map[string]*schema.Schema{
@@ -30,6 +35,10 @@ var resourcePolicyRuleSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Dont use this field if you want to generate a new ID.",
 		Default:     DynamicPolicyID,
+		// A dynamic ID is whatever has been allocated at creation
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return old != "" && new == strconv.Itoa(DynamicPolicyID)
+		},
 	},
 	"comment": {
 		Type:        schema.TypeString,
@@ -47,9 +56,11 @@ var resourcePolicyRuleSchema = map[string]*schema.Schema{
 		Description: "Specify the destination GROUP where these connections are allowed or denied.",
 	},
 	"ports": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "If there are specific ports to limit this rule to, enter them here. For ICMP traffic, enter icmp. Sample: 80,tcp/8080,udp/6142-6150,tcp/any,udp/any,icmp,any",
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "If there are specific ports to limit this rule to, enter them here. For ICMP traffic, enter icmp. Sample: 80,tcp/8080,udp/6142-6150,tcp/any,udp/any,icmp,any",
+		ValidateDiagFunc: validation.ToDiagFunc(validatePolicyPorts),
+		DiffSuppressFunc: diffSuppressPolicyPorts,
 	},
 	"action": {
 		Type:        schema.TypeString,
@@ -57,10 +68,14 @@ var resourcePolicyRuleSchema = map[string]*schema.Schema{
 		Description: "Action when this policy is triggered.",
 	},
 	"applications": {
-		Type:        schema.TypeList,
-		Required:    true,
-		Elem:        &schema.Schema{Type: schema.TypeString},
-		Description: "Enter applications for NeuVector to allow or deny. NeuVector understands deep application behavior and will analyze the payload to determine application protocols. Protocols include HTTP, HTTPS, SSL, SSH, DNS, DNCP, NTP, TFTP, ECHO, RTSP, SIP, MySQL, Redis, Zookeeper, Cassandra, MongoDB, PostgresSQL, Kafka, Couchbase, ActiveMQ, ElasticSearch, RabbitMQ, Radius, VoltDB, Consul, Syslog, Etcd, Spark, Apache, Nginx, Jetty, NodeJS, Oracle, MSSQL, Memcached and gRPC. To select everything enter \"any\"",
+		Type:     schema.TypeList,
+		Required: true,
+		Elem: &schema.Schema{
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validation.ToDiagFunc(validatePolicyApplication),
+		},
+		DiffSuppressFunc: diffSuppressPolicyApplications,
+		Description:      "Enter applications for NeuVector to allow or deny. NeuVector understands deep application behavior and will analyze the payload to determine application protocols. Protocols include HTTP, HTTPS, SSL, SSH, DNS, DHCP, NTP, TFTP, ECHO, RTSP, SIP, MySQL, Redis, Zookeeper, Cassandra, MongoDB, PostgreSQL, Kafka, Couchbase, ActiveMQ, ElasticSearch, RabbitMQ, Radius, VoltDB, Consul, Syslog, Etcd, Spark, Apache, Nginx, Jetty, NodeJS, Oracle, MSSQL, Memcached and gRPC. To select everything enter \"any\"",
 	},
 	"learned": {
 		Type:        schema.TypeBool,
@@ -101,7 +116,14 @@ func readPolicyRule(_map map[string]any) (*goneuvector.PolicyRule, error) {
 		return nil, err
 	}
 
+	for i, application := range applications {
+		if normalized := NormalizePolicyApplication(application); normalized != "" {
+			applications[i] = normalized
+		}
+	}
+
 	policy.Applications = applications
+	policy.Ports = NormalizePolicyPorts(policy.Ports)
 	policy.ID = policyID
 
 	return &policy, nil
@@ -112,22 +134,89 @@ func readPolicyRules(set []any) []goneuvector.PolicyRule {
 	return helper.FromTypeSetCallback(set, readPolicyRule)
 }
 
+// Hash a policy rule without its ID
+//
+// A dynamic rule is configured with `DynamicPolicyID` but stored with
+// the allocated one, both must identify the same set element.
+// The ports and applications are normalized for the same reason.
+func hashPolicyRule(v any) int {
+	ruleSchema := map[string]*schema.Schema{}
+	rule := map[string]any{}
+
+	for k, s := range resourcePolicyRuleSchema {
+		if k != "policy_id" {
+			ruleSchema[k] = s
+		}
+	}
+
+	for k, value := range v.(map[string]any) {
+		rule[k] = value
+	}
+
+	if ports, ok := rule["ports"].(string); ok {
+		rule["ports"] = NormalizePolicyPorts(ports)
+	}
+
+	if applications, ok := rule["applications"].([]any); ok {
+		var normalized []any
+
+		for _, application := range applications {
+			normalized = append(normalized, strings.ToLower(application.(string)))
+		}
+
+		rule["applications"] = normalized
+	}
+
+	return schema.HashResource(&schema.Resource{Schema: ruleSchema})(rule)
+}
+
 // Omitting the `delete` field by choice
 // because as a Terraform resource, it is not revelant to delete policies
 // at the creation
 var resourcePolicySchema = map[string]*schema.Schema{
 	"rule": {
-		Type:        schema.TypeSet,
-		Required:    true,
-		Description: "Matching criteria applied associated with the rule.",
+		Type:         schema.TypeSet,
+		Optional:     true,
+		Description:  "Matching criteria applied associated with the rule.",
+		ExactlyOneOf: []string{"rule", "ordered_rule"},
+		Elem: &schema.Resource{
+			Schema: resourcePolicyRuleSchema,
+		},
+		Set: hashPolicyRule,
+	},
+	"ordered_rule": {
+		Type:         schema.TypeList,
+		Optional:     true,
+		Description:  "Rules kept contiguous and evaluated in the configuration order.",
+		ExactlyOneOf: []string{"rule", "ordered_rule"},
 		Elem: &schema.Resource{
 			Schema: resourcePolicyRuleSchema,
 		},
 	},
+	"insert_after_id": {
+		Type:          schema.TypeInt,
+		Optional:      true,
+		Description:   "Place the rules right after the rule with this ID.",
+		ConflictsWith: []string{"insert_before_id", "position"},
+	},
+	"insert_before_id": {
+		Type:          schema.TypeInt,
+		Optional:      true,
+		Description:   "Place the rules right before the rule with this ID.",
+		ConflictsWith: []string{"insert_after_id", "position"},
+	},
+	"position": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		Description:   "Place the rules at the `top` or at the `bottom` of the policy.",
+		ValidateFunc:  validation.StringInSlice([]string{"top", "bottom"}, false),
+		ConflictsWith: []string{"insert_after_id", "insert_before_id"},
+	},
 	"rules_scope": {
 		Type:        schema.TypeString,
 		Optional:    true,
-		Description: "Scope applied to every rules, it helps definin the url.",
+		ForceNew:    true,
+		Description: policyScopeDescription,
 		Default:     DefaultScope,
 	},
 	"policy_ids": {
@@ -136,6 +225,7 @@ var resourcePolicySchema = map[string]*schema.Schema{
 		Description: "Contains every policy ID including the dynamic ones.",
 		Elem:        &schema.Schema{Type: schema.TypeInt},
 	},
+	"managed_cluster_id": managedClusterIDSchema,
 }
 
 func ResourcePolicy() *schema.Resource {
@@ -196,30 +286,61 @@ func GetScopeChanges(scopeName string) *ScopeChanges {
 	return ret
 }
 
-// Patch a policy rule, taking care of the scope
-func patchPolicy(APIClient *goneuvector.Client, body *goneuvector.PatchPolicyBody, scopeName string) error {
-	// Get the dynamic rules index in body.Rules
-	// Used to determinate the amount of need available index
-	indexes := GetDynamicPolicyIndexes(&body.Rules)
-	params := GetScopeChanges(scopeName)
-
-	// Get every available policy IDs
-	policyIDs, err := APIClient.GetPolicyAvailableIDs(
-		params.minID,
-		params.maxID,
-		len(indexes),
-	)
+// Returns the scope name of the range `id` belongs to
+func GetPolicyIDScope(id int) string {
+	for name, params := range scopes {
+		if id >= params.minID && id < params.maxID {
+			return name
+		}
+	}
 
-	if err != nil {
-		return err
+	return DefaultScope
+}
+
+// Returns whether `id` belongs to the range of the scope `scopeName`
+func isPolicyIDInScope(id int, scopeName string) bool {
+	scope := GetScopeChanges(scopeName)
+
+	return id >= scope.minID && id < scope.maxID
+}
+
+// Returns the policy rules of a scope
+func getPolicies(APIClient *goneuvector.Client, scopeName string) (*goneuvector.GetPoliciesResponse, error) {
+	var ret goneuvector.GetPoliciesResponse
+
+	if !GetScopeChanges(scopeName).IsFed {
+		return APIClient.GetPolicies()
 	}
 
-	// Updating the body with the new IDs if needed
-	for i, index := range indexes {
-		body.Rules[index].ID = policyIDs[i]
+	if err := APIClient.Get("/policy/rule?scope=fed", &ret); err != nil {
+		return nil, err
 	}
 
-	return APIClient.PatchPolicy(*body, params.IsFed)
+	return &ret, nil
+}
+
+// Returns a policy rule of a scope
+func getPolicy(APIClient *goneuvector.Client, id int, scopeName string) (*goneuvector.GetPolicyResponse, error) {
+	var ret goneuvector.GetPolicyResponse
+
+	if !GetScopeChanges(scopeName).IsFed {
+		return APIClient.GetPolicy(id)
+	}
+
+	url := fmt.Sprintf("/policy/rule/%d?scope=fed", id)
+
+	if err := APIClient.Get(url, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// Patch a policy rule, taking care of the scope
+//
+// The dynamic IDs are allocated by the provider wide allocator.
+func patchPolicy(meta any, APIClient *goneuvector.Client, body *goneuvector.PatchPolicyBody, scopeName string) error {
+	return meta.(*Meta).PolicyIDs.Patch(APIClient, body, scopeName)
 }
 
 func GetPolicyRuleMap(p *goneuvector.PolicyRule) *map[string]any {
@@ -255,20 +376,75 @@ func GetPolicyRulesSet(policies *[]goneuvector.PolicyRule) []map[string]any {
 	return ret
 }
 
+// Returns the key of the configured rules
+func getPolicyRulesKey(d *schema.ResourceData) string {
+	if _, ok := d.GetOk("ordered_rule"); ok {
+		return "ordered_rule"
+	}
+
+	return "rule"
+}
+
+// Returns the configured rules
+//
+// The state IDs of the ordered rules follow their position,
+// so the dynamic ones are reset to be matched by content.
+func getPolicyRules(d *schema.ResourceData) []goneuvector.PolicyRule {
+	if _, ok := d.GetOk("ordered_rule"); !ok {
+		return readPolicyRules(d.Get("rule").(*schema.Set).List())
+	}
+
+	rules := readPolicyRules(d.Get("ordered_rule").([]any))
+	raw := d.GetRawConfig()
+
+	if raw.IsNull() || !raw.IsKnown() {
+		return rules
+	}
+
+	rulesRaw := raw.GetAttr("ordered_rule")
+
+	if rulesRaw.IsNull() || !rulesRaw.IsKnown() {
+		return rules
+	}
+
+	for i, ruleRaw := range rulesRaw.AsValueSlice() {
+		if i < len(rules) && ruleRaw.GetAttr("policy_id").IsNull() {
+			rules[i].ID = DynamicPolicyID
+		}
+	}
+
+	return rules
+}
+
+// Returns the IDs of `rules`, in order
+func getPolicyRulesIDs(rules []goneuvector.PolicyRule) []int {
+	var ret []int
+
+	for _, rule := range rules {
+		ret = append(ret, rule.ID)
+	}
+
+	return ret
+}
+
+// Sets the rules and their IDs
+func setPolicyRules(d *schema.ResourceData, rules []goneuvector.PolicyRule) {
+	d.Set(getPolicyRulesKey(d), GetPolicyRulesSet(&rules))
+	d.Set("policy_ids", getPolicyRulesIDs(rules))
+}
+
 func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	var err error
 
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
-	rulesRaw := d.Get("rule").(*schema.Set).List()
 	body := goneuvector.PatchPolicyBody{
-		Rules: readPolicyRules(rulesRaw),
+		Rules: getPolicyRules(d),
 	}
 
-	APIClient.WithContext(ctx)
-
 	// Patching policy handling the configuration scope
 	err = patchPolicy(
+		meta,
 		APIClient,
 		&body,
 		d.Get("rules_scope").(string),
@@ -286,32 +462,179 @@ func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, meta any)
 		return diag.FromErr(err)
 	}
 
-	// Update policy_ids
-	var policy_ids []int
+	setPolicyRules(d, body.Rules)
+	d.SetId(id)
 
-	for _, rule := range body.Rules {
-		policy_ids = append(policy_ids, rule.ID)
-	}
+	err = placePolicyRules(
+		APIClient,
+		d,
+		getPolicyRulesIDs(body.Rules),
+		d.Get("rules_scope").(string),
+	)
 
-	d.Set("rule", GetPolicyRulesSet(&body.Rules))
-	d.Set("policy_ids", policy_ids)
-	d.SetId(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourcePolicyRead(ctx, d, meta)
 }
 
+// Returns the body patching the `old` rules into the `new` ones
+//
+// A dynamic rule in `new` takes back the ID of an equivalent old rule,
+// otherwise the one of the remaining old rule at the same index if
+// `ordered`, or between the same groups. Editing a rule then modifies
+// it in place instead of recreating it with a new ID and position.
+func DiffPolicyRules(old []goneuvector.PolicyRule, new []goneuvector.PolicyRule, ordered bool) goneuvector.PatchPolicyBody {
+	var body goneuvector.PatchPolicyBody
+
+	oldRules := map[int]goneuvector.PolicyRule{}
+	claimed := map[int]bool{}
+
+	for _, p := range old {
+		oldRules[p.ID] = p
+	}
+
+	for _, p := range new {
+		if p.ID != DynamicPolicyID {
+			claimed[p.ID] = true
+		}
+	}
+
+	// Unchanged rules first, so a modified one cannot take their ID
+	for i := range new {
+		p := &new[i]
+
+		if p.ID != DynamicPolicyID {
+			continue
+		}
+
+		for _, o := range old {
+			if !claimed[o.ID] && o.Equal(p) {
+				p.ID = o.ID
+				claimed[o.ID] = true
+				break
+			}
+		}
+	}
+
+	for i := range new {
+		p := &new[i]
+
+		if p.ID != DynamicPolicyID {
+			continue
+		}
+
+		for j, o := range old {
+			if claimed[o.ID] {
+				continue
+			}
+
+			if (ordered && i == j) || (!ordered && o.From == p.From && o.To == p.To) {
+				p.ID = o.ID
+				claimed[o.ID] = true
+				break
+			}
+		}
+	}
+
+	for _, p := range new {
+		if o, ok := oldRules[p.ID]; ok && reflect.DeepEqual(o, p) {
+			continue
+		}
+
+		body.Rules = append(body.Rules, p)
+	}
+
+	for _, o := range old {
+		if !claimed[o.ID] {
+			body.Delete = append(body.Delete, o.ID)
+		}
+	}
+
+	return body
+}
+
+// Reports the IDs allocated in `patched` to the dynamic rules of `rules`
+func SetDynamicPolicyIDs(rules []goneuvector.PolicyRule, patched []goneuvector.PolicyRule, indexes []int) {
+	i := 0
+
+	for j := range rules {
+		if rules[j].ID != DynamicPolicyID {
+			continue
+		}
+
+		rules[j].ID = patched[indexes[i]].ID
+		i++
+	}
+}
+
 func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	return nil
+	APIClient := getManagedClusterClient(ctx, d, meta)
+
+	oldRaw, _ := d.GetChange("rule")
+	oldOrderedRaw, _ := d.GetChange("ordered_rule")
+
+	old := append(
+		readPolicyRules(oldRaw.(*schema.Set).List()),
+		readPolicyRules(oldOrderedRaw.([]any))...,
+	)
+	rules := getPolicyRules(d)
+	body := DiffPolicyRules(old, rules, getPolicyRulesKey(d) == "ordered_rule")
+
+	if len(body.Rules) > 0 || len(body.Delete) > 0 {
+		indexes := GetDynamicPolicyIndexes(&body.Rules)
+		err := patchPolicy(
+			meta,
+			APIClient,
+			&body,
+			d.Get("rules_scope").(string),
+		)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		SetDynamicPolicyIDs(rules, body.Rules, indexes)
+	}
+
+	setPolicyRules(d, rules)
+
+	err := placePolicyRules(
+		APIClient,
+		d,
+		getPolicyRulesIDs(rules),
+		d.Get("rules_scope").(string),
+	)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePolicyRead(ctx, d, meta)
+}
+
+// Returns whether `p` replaced the managed rule `stored` outside of Terraform,
+// for example a learned rule taking its ID
+//
+// The `cfg_type` isn't compared, the controller reporting its own
+// value for the rules of a scope whatever the configured one.
+func IsForeignPolicyRule(p *goneuvector.PolicyRule, stored *goneuvector.PolicyRule, scopeName string) bool {
+	if !isPolicyIDInScope(p.ID, scopeName) {
+		return true
+	}
+
+	return stored != nil && p.Learned != stored.Learned
 }
 
 func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
 	var rules []map[string]any
+	var ids []int
 
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
-	policies, err := APIClient.
-		WithContext(ctx).
-		GetPolicies()
+	policies, err := getPolicies(APIClient, d.Get("rules_scope").(string))
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -324,11 +647,30 @@ func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, meta any) d
 		return diag.FromErr(err)
 	}
 
+	// Rules known by the state, used to detect the foreign ones
+	stored := map[int]*goneuvector.PolicyRule{}
+	key := getPolicyRulesKey(d)
+
+	var storedRaw []any
+
+	if key == "rule" {
+		storedRaw = d.Get(key).(*schema.Set).List()
+	} else {
+		storedRaw = d.Get(key).([]any)
+	}
+
+	for _, p := range readPolicyRules(storedRaw) {
+		p := p
+		stored[p.ID] = &p
+	}
+
 	// Creating a set of rules to inject it into the resource
 	for _, p := range policies.Rules {
-		exists, _ := util.ItemExists(policiesIDs, p.ID)
+		if indexOfPolicyID(policiesIDs, p.ID) == -1 {
+			continue
+		}
 
-		if !exists {
+		if IsForeignPolicyRule(&p, stored[p.ID], d.Get("rules_scope").(string)) {
 			continue
 		}
 
@@ -339,15 +681,35 @@ func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, meta any) d
 		}
 
 		rules = append(rules, *rule)
+		ids = append(ids, p.ID)
 	}
 
-	d.Set("rule", rules)
+	// Every rule has been deleted outside of Terraform
+	if len(ids) == 0 && len(policiesIDs) > 0 {
+		d.SetId("")
+		return nil
+	}
 
-	return nil
+	if drifted := excludePolicyIDs(policiesIDs, ids); len(drifted) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Policy rules changed outside of Terraform",
+			Detail: fmt.Sprintf(
+				"The rules %v have been deleted or replaced, they will be created again.",
+				drifted,
+			),
+		})
+	}
+
+	d.Set(key, rules)
+	d.Set("policy_ids", ids)
+	readPolicyPlacement(d, policies.GetPolicyIDs(), ids)
+
+	return diags
 }
 
 func resourcePolicyDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
 	params := GetScopeChanges(d.Get("rules_scope").(string))
 
@@ -358,20 +720,22 @@ func resourcePolicyDelete(ctx context.Context, d *schema.ResourceData, meta any)
 		return diag.FromErr(err)
 	}
 
-	APIClient.
-		WithContext(ctx).
-		PatchPolicy(
-			goneuvector.PatchPolicyBody{
-				Delete: delete,
-			},
-			params.IsFed,
-		)
+	err = APIClient.PatchPolicy(
+		goneuvector.PatchPolicyBody{
+			Delete: delete,
+		},
+		params.IsFed,
+	)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
 func resourcePolicyImport(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
 	ruleID, err := strconv.Atoi(d.Id())
 
@@ -379,9 +743,17 @@ func resourcePolicyImport(ctx context.Context, d *schema.ResourceData, meta any)
 		return nil, err
 	}
 
-	p, err := APIClient.
-		WithContext(ctx).
-		GetPolicy(ruleID)
+	scopeName := GetPolicyIDScope(ruleID)
+
+	// Learned rules for example, which the resource would drop as foreign
+	if !isPolicyIDInScope(ruleID, scopeName) {
+		return nil, fmt.Errorf(
+			"the policy rule %d is neither a user created nor a federal rule, it cannot be imported",
+			ruleID,
+		)
+	}
+
+	p, err := getPolicy(APIClient, ruleID, scopeName)
 
 	if err != nil {
 		return nil, err
@@ -394,7 +766,7 @@ func resourcePolicyImport(ctx context.Context, d *schema.ResourceData, meta any)
 		return nil, err
 	}
 
-	d.Set("rules_scope", rule.CfgType)
+	d.Set("rules_scope", scopeName)
 	d.Set("policy_ids", []int{rule.ID})
 	d.SetId(id)
 
@@ -0,0 +1,255 @@
+// resource_policy_promotion.go
+package neuvector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/helper"
+)
+
+var resourcePolicyPromotionSchema = map[string]*schema.Schema{
+	"policy_ids": {
+		Type:          schema.TypeSet,
+		Optional:      true,
+		ForceNew:      true,
+		Description:   "IDs of the learned rules to promote.",
+		Elem:          &schema.Schema{Type: schema.TypeInt},
+		ConflictsWith: []string{"from", "to"},
+		AtLeastOneOf:  []string{"policy_ids", "from", "to"},
+	},
+	"from": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Promote the learned rules whose connections originate from this group.",
+	},
+	"to": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Promote the learned rules whose connections go to this group.",
+	},
+	"promoted_ids": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "IDs of the user created rules, in the order of the promoted ones.",
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+	},
+	"managed_cluster_id": managedClusterIDSchema,
+}
+
+func ResourcePolicyPromotion() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Promotes learned network rules to user created rules. Each learned rule is recreated as a user created rule with a new ID, moved right after it to keep the evaluation order, then the learned rule is deleted.",
+		CreateContext: resourcePolicyPromotionCreate,
+		ReadContext:   resourcePolicyPromotionRead,
+		DeleteContext: resourcePolicyPromotionDelete,
+
+		Schema: resourcePolicyPromotionSchema,
+	}
+}
+
+// Returns whether `p` has been learned by NeuVector
+func isLearnedPolicyRule(p *goneuvector.PolicyRule) bool {
+	return p.Learned || p.CfgType == "learned"
+}
+
+// Returns the learned rules selected by the resource
+func getPromotedPolicyRules(d *schema.ResourceData, policies []goneuvector.PolicyRule) ([]goneuvector.PolicyRule, error) {
+	var ret []goneuvector.PolicyRule
+
+	idsRaw := d.Get("policy_ids").(*schema.Set).List()
+	ids, err := helper.FromSlice[int](idsRaw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	from := d.Get("from").(string)
+	to := d.Get("to").(string)
+
+	for _, p := range policies {
+		if len(ids) > 0 && indexOfPolicyID(ids, p.ID) == -1 {
+			continue
+		}
+
+		if (from != "" && p.From != from) || (to != "" && p.To != to) {
+			continue
+		}
+
+		if !isLearnedPolicyRule(&p) {
+			if len(ids) > 0 {
+				return nil, fmt.Errorf("the policy rule %d has not been learned", p.ID)
+			}
+
+			continue
+		}
+
+		ret = append(ret, p)
+	}
+
+	if len(ids) > 0 && len(ret) != len(ids) {
+		missing := excludePolicyIDs(ids, getPolicyRulesIDs(ret))
+
+		return nil, fmt.Errorf("the policy rules %v don't exist", missing)
+	}
+
+	return ret, nil
+}
+
+// Replaces the selected learned rules by user created copies,
+// which can then be adopted by a `neuvector_policy`
+//
+// Every copy is moved right after its learned rule before the learned
+// ones are deleted, so the evaluation order is kept.
+func resourcePolicyPromotionCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	APIClient := getManagedClusterClient(ctx, d, meta)
+
+	policies, err := APIClient.GetPolicies()
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	learned, err := getPromotedPolicyRules(d, policies.Rules)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(learned) == 0 {
+		return diag.Errorf("There is no learned rule to promote.")
+	}
+
+	body := goneuvector.PatchPolicyBody{}
+
+	for _, p := range learned {
+		p.ID = DynamicPolicyID
+		p.Learned = false
+		p.CfgType = DefaultScope
+		p.CreatedTimestamp = 0
+		p.LastModifiedTimestamp = 0
+
+		body.Rules = append(body.Rules, p)
+	}
+
+	err = patchPolicy(meta, APIClient, &body, DefaultScope)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := uuid.GenerateUUID()
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	promotedIDs := getPolicyRulesIDs(body.Rules)
+	err = replacePolicyRules(APIClient, learned, promotedIDs)
+
+	if err != nil {
+		// Deleting the copies, the learned rules left are promoted again
+		// by the next apply
+		cleanupErr := APIClient.PatchPolicy(
+			goneuvector.PatchPolicyBody{
+				Delete: promotedIDs,
+			},
+			false,
+		)
+
+		// Tracking the copies left, the resource is then tainted
+		if cleanupErr != nil {
+			d.SetId(id)
+			d.Set("promoted_ids", promotedIDs)
+
+			return diag.Errorf(
+				"%s, the promoted rules %v could not be deleted: %s",
+				err,
+				promotedIDs,
+				cleanupErr,
+			)
+		}
+
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("promoted_ids", promotedIDs)
+
+	return resourcePolicyPromotionRead(ctx, d, meta)
+}
+
+// Moves the rules `ids` after the `learned` rules they copy,
+// then deletes the learned rules
+func replacePolicyRules(APIClient *goneuvector.Client, learned []goneuvector.PolicyRule, ids []int) error {
+	for i, p := range learned {
+		err := APIClient.PatchPolicy(
+			goneuvector.PatchPolicyBody{
+				Move: &goneuvector.PolicyRuleMove{
+					After: p.ID,
+					ID:    ids[i],
+				},
+			},
+			false,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return APIClient.PatchPolicy(
+		goneuvector.PatchPolicyBody{
+			Delete: getPolicyRulesIDs(learned),
+		},
+		false,
+	)
+}
+
+// Forgets the promoted rules deleted since, the promotion is done
+// again once every one of them is gone
+func resourcePolicyPromotionRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var ids []int
+
+	APIClient := getManagedClusterClient(ctx, d, meta)
+
+	policies, err := APIClient.GetPolicies()
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	promotedIDs, err := helper.FromSlice[int](d.Get("promoted_ids").([]any))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current := policies.GetPolicyIDs()
+
+	for _, id := range promotedIDs {
+		if indexOfPolicyID(current, id) != -1 {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("promoted_ids", ids)
+
+	return nil
+}
+
+// The promoted rules are left in place, only the promotion is forgotten
+func resourcePolicyPromotionDelete(_ context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+	return nil
+}
@@ -0,0 +1,44 @@
+package neuvector_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/testutils"
+)
+
+// Skips the test unless the controller learned a rule from the group `from`
+func testAccPreCheckLearnedPolicyRule(t *testing.T, from string) {
+	APIClient := testutils.Provider.Meta().(*neuvector.Meta).Client
+
+	policies, err := APIClient.GetPolicies()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range policies.Rules {
+		if p.Learned && p.From == from {
+			return
+		}
+	}
+
+	t.Skipf("the controller has no learned rule from %q to promote", from)
+}
+
+func TestAccResourcePolicyPromotion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckLearnedPolicyRule(t, "nodes") },
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExampleFile(t, "resources/neuvector_policy_promotion/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("neuvector_policy_promotion.test", "promoted_ids.#"),
+					resource.TestCheckResourceAttr("neuvector_policy_promotion.test", "from", "nodes"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,200 @@
+// resource_policy_rule.go
+package neuvector
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+)
+
+// Returns the schema of a single policy rule,
+// its ID being the resource one
+func getResourcePolicyRuleSchema() map[string]*schema.Schema {
+	ret := map[string]*schema.Schema{
+		"policy_id": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "The rule ID, a new one is allocated in the `rules_scope` range if omitted.",
+		},
+		"rules_scope": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: policyScopeDescription,
+			Default:     DefaultScope,
+		},
+		"managed_cluster_id": managedClusterIDSchema,
+	}
+
+	for k, s := range resourcePolicyRuleSchema {
+		if _, ok := ret[k]; !ok {
+			ret[k] = s
+		}
+	}
+
+	return ret
+}
+
+func ResourcePolicyRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePolicyRuleCreate,
+		ReadContext:   resourcePolicyRuleRead,
+		DeleteContext: resourcePolicyRuleDelete,
+		UpdateContext: resourcePolicyRuleUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePolicyRuleImport,
+		},
+
+		Schema: getResourcePolicyRuleSchema(),
+	}
+}
+
+// Read the policy rule configured in `d`
+func readResourcePolicyRule(d *schema.ResourceData) (*goneuvector.PolicyRule, error) {
+	_map := map[string]any{}
+
+	for k := range resourcePolicyRuleSchema {
+		_map[k] = d.Get(k)
+	}
+
+	if _, ok := d.GetOk("policy_id"); !ok {
+		_map["policy_id"] = DynamicPolicyID
+	}
+
+	return readPolicyRule(_map)
+}
+
+func resourcePolicyRuleCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	APIClient := getManagedClusterClient(ctx, d, meta)
+
+	rule, err := readResourcePolicyRule(d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	body := goneuvector.PatchPolicyBody{
+		Rules: []goneuvector.PolicyRule{*rule},
+	}
+
+	err = patchPolicy(
+		meta,
+		APIClient,
+		&body,
+		d.Get("rules_scope").(string),
+	)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(body.Rules[0].ID))
+
+	return resourcePolicyRuleRead(ctx, d, meta)
+}
+
+func resourcePolicyRuleUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	APIClient := getManagedClusterClient(ctx, d, meta)
+	params := GetScopeChanges(d.Get("rules_scope").(string))
+
+	rule, err := readResourcePolicyRule(d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = APIClient.PatchPolicy(
+		goneuvector.PatchPolicyBody{
+			Rules: []goneuvector.PolicyRule{*rule},
+		},
+		params.IsFed,
+	)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePolicyRuleRead(ctx, d, meta)
+}
+
+func resourcePolicyRuleRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	APIClient := getManagedClusterClient(ctx, d, meta)
+
+	id, err := strconv.Atoi(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	p, err := getPolicy(APIClient, id, d.Get("rules_scope").(string))
+
+	if isNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule := GetPolicyRuleMap(&p.Rule)
+
+	if rule == nil {
+		return nil
+	}
+
+	for k, v := range *rule {
+		d.Set(k, v)
+	}
+
+	return nil
+}
+
+func resourcePolicyRuleDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	APIClient := getManagedClusterClient(ctx, d, meta)
+	params := GetScopeChanges(d.Get("rules_scope").(string))
+
+	id, err := strconv.Atoi(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = APIClient.PatchPolicy(
+		goneuvector.PatchPolicyBody{
+			Delete: []int{id},
+		},
+		params.IsFed,
+	)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePolicyRuleImport(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	APIClient := getManagedClusterClient(ctx, d, meta)
+
+	id, err := strconv.Atoi(d.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	scopeName := GetPolicyIDScope(id)
+
+	if _, err := getPolicy(APIClient, id, scopeName); err != nil {
+		return nil, err
+	}
+
+	d.Set("rules_scope", scopeName)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,29 @@
+package neuvector_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/theobori/terraform-provider-neuvector/internal/testutils"
+)
+
+func TestAccResourcePolicyRule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExampleFile(t, "resources/neuvector_policy_rule/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("neuvector_policy_rule.test", "policy_id"),
+					resource.TestCheckResourceAttr("neuvector_policy_rule.test", "ports", "tcp/80"),
+					resource.TestCheckResourceAttr("neuvector_policy_rule.test", "rules_scope", "user_created"),
+				),
+			},
+			{
+				ResourceName:      "neuvector_policy_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,315 @@
+package neuvector_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/testutils"
+)
+
+const testAccResourcePolicyUpdated = `
+resource "neuvector_policy" "test" {
+  rule {
+    action       = "allow"
+    applications = ["any"]
+    comment      = "Containers constraints"
+    from         = "containers"
+    to           = "containers"
+    ports        = "any"
+  }
+
+  rule {
+    policy_id    = 123
+    action       = "deny"
+    applications = ["HTTP"]
+    comment      = "Nodes web constraints"
+    from         = "nodes"
+    to           = "containers"
+    ports        = "tcp/80"
+  }
+}
+`
+
+func testAccDeletePolicyRule(t *testing.T, id int) func() {
+	return func() {
+		APIClient := testutils.Provider.Meta().(*neuvector.Meta).Client
+
+		err := APIClient.PatchPolicy(
+			goneuvector.PatchPolicyBody{
+				Delete: []int{id},
+			},
+			false,
+		)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAccResourcePolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExampleFile(t, "resources/neuvector_policy/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("neuvector_policy.test", "rule.#", "3"),
+					resource.TestCheckResourceAttr("neuvector_policy.test", "policy_ids.#", "3"),
+					resource.TestCheckResourceAttr("neuvector_policy.ordered", "ordered_rule.#", "2"),
+					resource.TestCheckResourceAttr("neuvector_policy.ordered", "ordered_rule.0.comment", "Containers DNS"),
+					resource.TestCheckResourceAttr("neuvector_policy.ordered", "position", "top"),
+				),
+			},
+			{
+				// Deleted outside of Terraform, then created again
+				PreConfig: testAccDeletePolicyRule(t, 123),
+				Config:    testutils.TestAccExampleFile(t, "resources/neuvector_policy/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("neuvector_policy.test", "policy_ids.#", "3"),
+					resource.TestCheckTypeSetElemAttr("neuvector_policy.test", "policy_ids.*", "123"),
+				),
+			},
+			{
+				Config: testAccResourcePolicyUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("neuvector_policy.test", "rule.#", "2"),
+					resource.TestCheckResourceAttr("neuvector_policy.test", "policy_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttr("neuvector_policy.test", "policy_ids.*", "123"),
+					resource.TestCheckTypeSetElemNestedAttrs("neuvector_policy.test", "rule.*", map[string]string{
+						"action":  "allow",
+						"comment": "Containers constraints",
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourcePolicyFederal = `
+resource "neuvector_policy" "fed_containers" {
+  rules_scope = "federal"
+
+  rule {
+    action       = "deny"
+    applications = ["any"]
+    comment      = "Containers constraints"
+    from         = "fed.containers"
+    to           = "fed.containers"
+    ports        = "any"
+    cfg_type     = "federal"
+  }
+}
+
+data "neuvector_policy_ids" "federation_rules" {
+  rules_scope = "federal"
+  cfg_type    = "federal"
+
+  depends_on = [neuvector_policy.fed_containers]
+}
+`
+
+func TestAccResourcePolicyFederal(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testutils.TestAccPreCheckFederation(t) },
+		ProviderFactories: testutils.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePolicyFederal,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("neuvector_policy.fed_containers", "rules_scope", "federal"),
+					resource.TestCheckResourceAttr("neuvector_policy.fed_containers", "rule.#", "1"),
+					resource.TestCheckResourceAttr("neuvector_policy.fed_containers", "policy_ids.#", "1"),
+					resource.TestCheckResourceAttrSet("data.neuvector_policy_ids.federation_rules", "ids.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestIsForeignPolicyRule(t *testing.T) {
+	managed := goneuvector.PolicyRule{ID: 123, CfgType: "user_created"}
+	fedManaged := goneuvector.PolicyRule{ID: 100123, CfgType: "user_created"}
+
+	tests := []struct {
+		name     string
+		rule     goneuvector.PolicyRule
+		stored   *goneuvector.PolicyRule
+		scope    string
+		expected bool
+	}{
+		{
+			name:   "managed rule",
+			rule:   goneuvector.PolicyRule{ID: 123, CfgType: "user_created"},
+			stored: &managed,
+			scope:  "user_created",
+		},
+		{
+			name:   "federal rule with the default cfg_type",
+			rule:   goneuvector.PolicyRule{ID: 100123, CfgType: "federal"},
+			stored: &fedManaged,
+			scope:  "federal",
+		},
+		{
+			name:     "out of the scope range",
+			rule:     goneuvector.PolicyRule{ID: 10123, Learned: true},
+			scope:    "user_created",
+			expected: true,
+		},
+		{
+			name:     "local rule in the federal scope",
+			rule:     goneuvector.PolicyRule{ID: 123},
+			scope:    "federal",
+			expected: true,
+		},
+		{
+			name:  "imported rule",
+			rule:  goneuvector.PolicyRule{ID: 123, CfgType: "user_created"},
+			scope: "user_created",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := neuvector.IsForeignPolicyRule(&test.rule, test.stored, test.scope)
+
+			if actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDiffPolicyRules(t *testing.T) {
+	web := goneuvector.PolicyRule{ID: 1, From: "containers", To: "nodes", Ports: "tcp/80", Action: "allow", Applications: []string{"HTTP"}}
+	dns := goneuvector.PolicyRule{ID: 2, From: "containers", To: "nodes", Ports: "udp/53", Action: "allow", Applications: []string{"DNS"}}
+	ssh := goneuvector.PolicyRule{ID: 3, From: "nodes", To: "containers", Ports: "tcp/22", Action: "deny", Applications: []string{"SSH"}}
+
+	dynamic := func(p goneuvector.PolicyRule) goneuvector.PolicyRule {
+		p.ID = neuvector.DynamicPolicyID
+		return p
+	}
+
+	modified := func(p goneuvector.PolicyRule, f func(*goneuvector.PolicyRule)) goneuvector.PolicyRule {
+		f(&p)
+		return p
+	}
+
+	tests := []struct {
+		name        string
+		old         []goneuvector.PolicyRule
+		new         []goneuvector.PolicyRule
+		ordered     bool
+		expectedIDs []int
+		rules       []int
+		deleted     []int
+	}{
+		{
+			name:        "unchanged",
+			old:         []goneuvector.PolicyRule{web, ssh},
+			new:         []goneuvector.PolicyRule{dynamic(ssh), dynamic(web)},
+			expectedIDs: []int{3, 1},
+		},
+		{
+			name: "action modified in place",
+			old:  []goneuvector.PolicyRule{web},
+			new: []goneuvector.PolicyRule{
+				modified(dynamic(web), func(p *goneuvector.PolicyRule) { p.Action = "deny" }),
+			},
+			expectedIDs: []int{1},
+			rules:       []int{1},
+		},
+		{
+			name: "ports modified between the same groups",
+			old:  []goneuvector.PolicyRule{web, ssh},
+			new: []goneuvector.PolicyRule{
+				dynamic(ssh),
+				modified(dynamic(web), func(p *goneuvector.PolicyRule) { p.Ports = "tcp/8080" }),
+			},
+			expectedIDs: []int{3, 1},
+			rules:       []int{1},
+		},
+		{
+			name: "ordered rule modified at the same index",
+			old:  []goneuvector.PolicyRule{web, dns},
+			new: []goneuvector.PolicyRule{
+				dynamic(web),
+				modified(dynamic(dns), func(p *goneuvector.PolicyRule) { p.Comment = "DNS" }),
+			},
+			ordered:     true,
+			expectedIDs: []int{1, 2},
+			rules:       []int{2},
+		},
+		{
+			name:        "new and deleted rules",
+			old:         []goneuvector.PolicyRule{web, ssh},
+			new:         []goneuvector.PolicyRule{dynamic(web), dynamic(dns)},
+			expectedIDs: []int{1, neuvector.DynamicPolicyID},
+			rules:       []int{neuvector.DynamicPolicyID},
+			deleted:     []int{3},
+		},
+		{
+			name:        "explicit ID kept",
+			old:         []goneuvector.PolicyRule{web},
+			new:         []goneuvector.PolicyRule{dns, dynamic(web)},
+			expectedIDs: []int{2, 1},
+			rules:       []int{2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body := neuvector.DiffPolicyRules(test.old, test.new, test.ordered)
+
+			var ids, rules []int
+
+			for _, p := range test.new {
+				ids = append(ids, p.ID)
+			}
+
+			for _, p := range body.Rules {
+				rules = append(rules, p.ID)
+			}
+
+			if !reflect.DeepEqual(ids, test.expectedIDs) {
+				t.Errorf("expected the IDs %v, got %v", test.expectedIDs, ids)
+			}
+
+			if !reflect.DeepEqual(rules, test.rules) {
+				t.Errorf("expected the patched rules %v, got %v", test.rules, rules)
+			}
+
+			if !reflect.DeepEqual(body.Delete, test.deleted) {
+				t.Errorf("expected the deleted rules %v, got %v", test.deleted, body.Delete)
+			}
+		})
+	}
+}
+
+func TestSetDynamicPolicyIDs(t *testing.T) {
+	rules := []goneuvector.PolicyRule{
+		{ID: neuvector.DynamicPolicyID},
+		{ID: 5},
+		{ID: neuvector.DynamicPolicyID},
+	}
+	patched := []goneuvector.PolicyRule{
+		{ID: 5},
+		{ID: 7},
+		{ID: 8},
+	}
+
+	neuvector.SetDynamicPolicyIDs(rules, patched, []int{1, 2})
+
+	var ids []int
+
+	for _, p := range rules {
+		ids = append(ids, p.ID)
+	}
+
+	if expected := []int{7, 5, 8}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
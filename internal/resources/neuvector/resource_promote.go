@@ -45,7 +45,7 @@ func ResourcePromote() *schema.Resource {
 }
 
 func resourcePromoteCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	masterRestInfo := helper.FromSchemas[goneuvector.MasterRestInfo](
 		resourcePromoteSchema,
@@ -76,7 +76,7 @@ func resourcePromoteRead(_ context.Context, d *schema.ResourceData, meta any) di
 }
 
 func resourcePromoteDelete(ctx context.Context, _ *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	if err := APIClient.WithContext(ctx).Demote(); err != nil {
 		return diag.FromErr(err)
@@ -94,7 +94,9 @@ var resourceRegistrySchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Indicates if the registry must be scanned immediatly after beeing added.",
 		Default:     false,
-	}}
+	},
+	"managed_cluster_id": managedClusterIDSchema,
+}
 
 func ResourceRegistry() *schema.Resource {
 	return &schema.Resource{
@@ -131,7 +133,7 @@ func readRegistry(d *schema.ResourceData) (*goneuvector.CreateRegistryBody, erro
 }
 
 func resourceRegistryCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
 	body, err := readRegistry(d)
 
@@ -139,7 +141,7 @@ func resourceRegistryCreate(ctx context.Context, d *schema.ResourceData, meta an
 		return diag.FromErr(err)
 	}
 
-	if err := APIClient.WithContext(ctx).CreateRegistry(*body); err != nil {
+	if err := APIClient.CreateRegistry(*body); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -167,7 +169,7 @@ func resourceRegistryCreate(ctx context.Context, d *schema.ResourceData, meta an
 }
 
 func resourceRegistryUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
 	if d.HasChanges("name", "registry_type") {
 		return diag.Errorf("You are not allowed to change the registry name and type.")
@@ -179,7 +181,7 @@ func resourceRegistryUpdate(ctx context.Context, d *schema.ResourceData, meta an
 		return diag.FromErr(err)
 	}
 
-	if err := APIClient.WithContext(ctx).PatchRegistry(*body, body.Name); err != nil {
+	if err := APIClient.PatchRegistry(*body, body.Name); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -189,11 +191,9 @@ func resourceRegistryUpdate(ctx context.Context, d *schema.ResourceData, meta an
 func resourceRegistryRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	var err error
 
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
-	r, err := APIClient.
-		WithContext(ctx).
-		GetRegistry(d.Id())
+	r, err := APIClient.GetRegistry(d.Id())
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -213,9 +213,9 @@ func resourceRegistryRead(ctx context.Context, d *schema.ResourceData, meta any)
 }
 
 func resourceRegistryDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getManagedClusterClient(ctx, d, meta)
 
-	if err := APIClient.WithContext(ctx).DeleteRegistry(d.Id()); err != nil {
+	if err := APIClient.DeleteRegistry(d.Id()); err != nil {
 		return diag.FromErr(err)
 	}
 
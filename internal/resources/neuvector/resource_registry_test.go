@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
 	"github.com/theobori/terraform-provider-neuvector/internal/testutils"
 )
 
@@ -48,7 +49,7 @@ func testAccRegistryCheckExists(rn string, r *goneuvector.Registry) resource.Tes
 			return fmt.Errorf("resource id not set")
 		}
 
-		APIClient := testutils.Provider.Meta().(*goneuvector.Client)
+		APIClient := testutils.Provider.Meta().(*neuvector.Meta).Client
 
 		registry, err := APIClient.GetRegistry(rs.Primary.ID)
 
@@ -64,7 +65,7 @@ func testAccRegistryCheckExists(rn string, r *goneuvector.Registry) resource.Tes
 
 func testAccRegistryCheckDestroy(r *goneuvector.Registry) resource.TestCheckFunc {
 	return func(_ *terraform.State) error {
-		APIClient := testutils.Provider.Meta().(*goneuvector.Client)
+		APIClient := testutils.Provider.Meta().(*neuvector.Meta).Client
 
 		_, err := APIClient.GetRegistry(r.Name)
 
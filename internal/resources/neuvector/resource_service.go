@@ -52,11 +52,25 @@ func ResourceService() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeDiffVersion(
+			versionRequirement{"baseline_profile", baselineProfileVersion},
+		),
 
 		Schema: resourceServiceSchema,
 	}
 }
 
+// Minimum controller version supporting `baseline_profile`
+const baselineProfileVersion = "5.0.0"
+
+// Returns whether the `baseline_profile` default must be left out,
+// the older controllers rejecting it
+func skipDefaultBaselineProfile(d *schema.ResourceData, meta any) bool {
+	m, ok := meta.(*Meta)
+
+	return ok && !m.supports(baselineProfileVersion) && !isConfigured(d, "baseline_profile")
+}
+
 func resolveGroupName(d *schema.ResourceData) string {
 	domain := d.Get("domain").(string)
 	name := "nv." + d.Id()
@@ -69,13 +83,17 @@ func resolveGroupName(d *schema.ResourceData) string {
 }
 
 func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	body := helper.FromSchemas[goneuvector.CreateServiceBody](
 		resourceServiceSchema,
 		d,
 	)
 
+	if skipDefaultBaselineProfile(d, meta) {
+		body.BaselineProfile = nil
+	}
+
 	if err := APIClient.CreateService(body); err != nil {
 		return diag.FromErr(err)
 	}
@@ -94,7 +112,7 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 		return nil
 	}
 
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	body := helper.FromSchemas[goneuvector.PatchServiceConfigBody](
 		resourceServiceSchema,
@@ -103,6 +121,10 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 
 	body.Services = []string{d.Id()}
 
+	if skipDefaultBaselineProfile(d, meta) {
+		body.BaselineProfile = nil
+	}
+
 	if err := APIClient.WithContext(ctx).PatchServiceConfig(body); err != nil {
 		return diag.FromErr(err)
 	}
@@ -111,7 +133,7 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 }
 
 func resourceServiceRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	s, err := APIClient.
 		WithContext(ctx).
@@ -125,6 +147,7 @@ func resourceServiceRead(ctx context.Context, d *schema.ResourceData, meta any)
 	// is not reported into the service. So, we temporarily
 	// store the comment.
 	comment := d.Get("comment").(string)
+	baselineProfile := d.Get("baseline_profile").(string)
 
 	if err := helper.TfFromStruct(s.Service, d, true); err != nil {
 		return diag.FromErr(err)
@@ -132,11 +155,16 @@ func resourceServiceRead(ctx context.Context, d *schema.ResourceData, meta any)
 
 	d.Set("comment", comment)
 
+	// Not reported by the older controllers
+	if s.Service.BaselineProfile == "" {
+		d.Set("baseline_profile", baselineProfile)
+	}
+
 	return nil
 }
 
 func resourceServiceDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	err := APIClient.
 		WithContext(ctx).
@@ -45,7 +45,7 @@ func ResourceServiceConfig() *schema.Resource {
 }
 
 func resourceServiceConfigCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	body := helper.FromSchemas[goneuvector.PatchServiceConfigBody](
 		resourceServiceConfigSchema,
@@ -81,7 +81,7 @@ func resourceServiceConfigRead(_ context.Context, d *schema.ResourceData, meta a
 }
 
 func resourceServiceConfigDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	servicesRaw := d.Get("services").([]any)
 	services, err := helper.FromSlice[string](servicesRaw)
@@ -109,7 +109,7 @@ func ResourceUser() *schema.Resource {
 }
 
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	body := helper.FromSchemas[goneuvector.User](resourceUserSchema, d)
 
@@ -129,7 +129,7 @@ func resourceUserUpdate(_ context.Context, d *schema.ResourceData, meta any) dia
 }
 
 func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	user, err := APIClient.
 		WithContext(ctx).
@@ -153,7 +153,7 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta any) dia
 }
 
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	if err := APIClient.WithContext(ctx).DeleteUser(d.Id()); err != nil {
 		return diag.FromErr(err)
@@ -89,7 +89,7 @@ func readUserRole(d *schema.ResourceData) goneuvector.CreateUserRoleBody {
 }
 
 func resourceUserRoleCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	body := readUserRole(d)
 
@@ -107,7 +107,7 @@ func resourceUserRoleUpdate(ctx context.Context, d *schema.ResourceData, meta an
 		return diag.Errorf("You are not allowed to change the role name.")
 	}
 
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	body := readUserRole(d)
 
@@ -119,7 +119,7 @@ func resourceUserRoleUpdate(ctx context.Context, d *schema.ResourceData, meta an
 }
 
 func resourceUserRoleRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	roleFull, err := APIClient.
 		WithContext(ctx).
@@ -141,7 +141,7 @@ func resourceUserRoleRead(ctx context.Context, d *schema.ResourceData, meta any)
 }
 
 func resourceUserRoleDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	APIClient := meta.(*goneuvector.Client)
+	APIClient := getAPIClient(ctx, meta)
 
 	if err := APIClient.WithContext(ctx).DeleteUserRole(d.Id()); err != nil {
 		return diag.FromErr(err)
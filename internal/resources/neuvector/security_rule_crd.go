@@ -0,0 +1,181 @@
+// security_rule_crd.go
+package neuvector
+
+import (
+	"bytes"
+	"fmt"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// API version of the NeuVector CRDs
+	securityRuleAPIVersion = "neuvector.com/v1"
+	// Kind of the namespaced groups rules
+	securityRuleKind = "NvSecurityRule"
+	// Kind of the cluster wide groups rules
+	clusterSecurityRuleKind = "NvClusterSecurityRule"
+)
+
+// Process rule of a group
+type ProcessRule struct {
+	Name   string `json:"name" yaml:"name"`
+	Path   string `json:"path" yaml:"path"`
+	Action string `json:"action" yaml:"action"`
+}
+
+// Response type of a group process profile
+type getProcessProfileResponse struct {
+	Profile struct {
+		ProcessList []ProcessRule `json:"process_list"`
+	} `json:"process_profile"`
+}
+
+// Group rendered as a security rule, with its process rules
+type SecurityRuleGroup struct {
+	Group     goneuvector.Group
+	Processes []ProcessRule
+}
+
+type securityRuleCriteria struct {
+	Key   string `yaml:"key"`
+	Value string `yaml:"value"`
+	Op    string `yaml:"op"`
+}
+
+type securityRuleSelector struct {
+	Name     string                 `yaml:"name"`
+	Criteria []securityRuleCriteria `yaml:"criteria,omitempty"`
+}
+
+type securityRuleTarget struct {
+	PolicyMode string               `yaml:"policymode,omitempty"`
+	Selector   securityRuleSelector `yaml:"selector"`
+}
+
+type securityRuleNetwork struct {
+	Name         string               `yaml:"name"`
+	Selector     securityRuleSelector `yaml:"selector"`
+	Action       string               `yaml:"action"`
+	Applications []string             `yaml:"applications"`
+	Ports        string               `yaml:"ports"`
+	Priority     int                  `yaml:"priority"`
+}
+
+type securityRuleSpec struct {
+	Target  securityRuleTarget    `yaml:"target"`
+	Ingress []securityRuleNetwork `yaml:"ingress"`
+	Egress  []securityRuleNetwork `yaml:"egress"`
+	Process []ProcessRule         `yaml:"process"`
+}
+
+type securityRuleMetadata struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+type securityRule struct {
+	APIVersion string               `yaml:"apiVersion"`
+	Kind       string               `yaml:"kind"`
+	Metadata   securityRuleMetadata `yaml:"metadata"`
+	Spec       securityRuleSpec     `yaml:"spec"`
+}
+
+// Returns the selector of a group
+func getSecurityRuleSelector(name string, criteria []goneuvector.GroupCriteria) securityRuleSelector {
+	ret := securityRuleSelector{
+		Name: name,
+	}
+
+	for _, c := range criteria {
+		ret.Criteria = append(ret.Criteria, securityRuleCriteria(c))
+	}
+
+	return ret
+}
+
+// Returns the namespace of a group, empty for the cluster wide ones
+func getSecurityRuleNamespace(criteria []goneuvector.GroupCriteria) string {
+	var ret string
+
+	for _, c := range criteria {
+		if c.Key != "domain" {
+			continue
+		}
+
+		if c.Op != "=" || ret != "" {
+			return ""
+		}
+
+		ret = c.Value
+	}
+
+	return ret
+}
+
+// Renders the groups, their network and process rules
+// as NvSecurityRule or NvClusterSecurityRule YAML documents
+//
+// `peers` contains the criteria of the groups the rules refer to.
+func RenderSecurityRules(groups []SecurityRuleGroup, peers map[string][]goneuvector.GroupCriteria) (string, error) {
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	for _, g := range groups {
+		group := g.Group
+		namespace := getSecurityRuleNamespace(group.Criteria)
+		rule := securityRule{
+			APIVersion: securityRuleAPIVersion,
+			Kind:       securityRuleKind,
+			Metadata: securityRuleMetadata{
+				Name:      group.Name,
+				Namespace: namespace,
+			},
+			Spec: securityRuleSpec{
+				Target: securityRuleTarget{
+					PolicyMode: group.PolicyMode,
+					Selector:   getSecurityRuleSelector(group.Name, group.Criteria),
+				},
+				Ingress: []securityRuleNetwork{},
+				Egress:  []securityRuleNetwork{},
+				Process: append([]ProcessRule{}, g.Processes...),
+			},
+		}
+
+		if namespace == "" {
+			rule.Kind = clusterSecurityRuleKind
+		}
+
+		for _, p := range group.PolicyRules {
+			network := securityRuleNetwork{
+				Action:       p.Action,
+				Applications: p.Applications,
+				Ports:        p.Ports,
+				Priority:     p.Priority,
+			}
+
+			if p.From == group.Name {
+				network.Name = fmt.Sprintf("%s-egress-%d", group.Name, len(rule.Spec.Egress))
+				network.Selector = getSecurityRuleSelector(p.To, peers[p.To])
+				rule.Spec.Egress = append(rule.Spec.Egress, network)
+			} else if p.To == group.Name {
+				network.Name = fmt.Sprintf("%s-ingress-%d", group.Name, len(rule.Spec.Ingress))
+				network.Selector = getSecurityRuleSelector(p.From, peers[p.From])
+				rule.Spec.Ingress = append(rule.Spec.Ingress, network)
+			}
+		}
+
+		if err := encoder.Encode(rule); err != nil {
+			return "", err
+		}
+	}
+
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
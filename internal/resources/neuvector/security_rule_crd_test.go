@@ -0,0 +1,113 @@
+package neuvector_test
+
+import (
+	"testing"
+
+	goneuvector "github.com/theobori/go-neuvector/neuvector"
+	"github.com/theobori/terraform-provider-neuvector/internal/resources/neuvector"
+)
+
+func TestRenderSecurityRules(t *testing.T) {
+	groups := []neuvector.SecurityRuleGroup{
+		{
+			Group: goneuvector.Group{
+				Name:       "nv.web.demo",
+				PolicyMode: "Protect",
+				Criteria: []goneuvector.GroupCriteria{
+					{Key: "service", Value: "web.demo", Op: "="},
+					{Key: "domain", Value: "demo", Op: "="},
+				},
+				PolicyRules: []goneuvector.PolicyRule{
+					policyRule(10, "nv.lb.demo", "nv.web.demo", "tcp/80", "allow", "HTTP"),
+					policyRule(11, "nv.web.demo", "external", "any", "deny", "any"),
+				},
+			},
+			Processes: []neuvector.ProcessRule{
+				{Name: "nginx", Path: "/usr/sbin/nginx", Action: "allow"},
+			},
+		},
+		{
+			Group: goneuvector.Group{
+				Name: "cluster",
+				Criteria: []goneuvector.GroupCriteria{
+					{Key: "label", Value: "app=cluster", Op: "="},
+				},
+			},
+		},
+	}
+
+	peers := map[string][]goneuvector.GroupCriteria{
+		"nv.lb.demo": {{Key: "service", Value: "lb.demo", Op: "="}},
+	}
+
+	expected := `apiVersion: neuvector.com/v1
+kind: NvSecurityRule
+metadata:
+  name: nv.web.demo
+  namespace: demo
+spec:
+  target:
+    policymode: Protect
+    selector:
+      name: nv.web.demo
+      criteria:
+        - key: service
+          value: web.demo
+          op: =
+        - key: domain
+          value: demo
+          op: =
+  ingress:
+    - name: nv.web.demo-ingress-0
+      selector:
+        name: nv.lb.demo
+        criteria:
+          - key: service
+            value: lb.demo
+            op: =
+      action: allow
+      applications:
+        - HTTP
+      ports: tcp/80
+      priority: 0
+  egress:
+    - name: nv.web.demo-egress-0
+      selector:
+        name: external
+      action: deny
+      applications:
+        - any
+      ports: any
+      priority: 0
+  process:
+    - name: nginx
+      path: /usr/sbin/nginx
+      action: allow
+---
+apiVersion: neuvector.com/v1
+kind: NvClusterSecurityRule
+metadata:
+  name: cluster
+spec:
+  target:
+    selector:
+      name: cluster
+      criteria:
+        - key: label
+          value: app=cluster
+          op: =
+  ingress: []
+  egress: []
+  process: []
+`
+
+	actual, err := neuvector.RenderSecurityRules(groups, peers)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
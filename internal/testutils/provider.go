@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -67,3 +68,11 @@ func testAccEnabled(name string) bool {
 
 	return enabled
 }
+
+// Skips the test unless the controller is a federation master,
+// as indicated by NEUVECTOR_FEDERATION_MASTER
+func TestAccPreCheckFederation(t *testing.T) {
+	if !testAccEnabled("NEUVECTOR_FEDERATION_MASTER") {
+		t.Skip("NEUVECTOR_FEDERATION_MASTER must be set to run the federal tests")
+	}
+}